@@ -4,11 +4,13 @@ go 1.22.7
 
 require (
 	github.com/hashicorp/terraform-plugin-framework v1.13.0
+	github.com/hashicorp/terraform-plugin-framework-validators v0.15.0
 	github.com/hashicorp/terraform-plugin-go v0.25.0
 	github.com/hashicorp/terraform-plugin-log v0.9.0
 	github.com/hashicorp/terraform-plugin-testing v1.10.0
 	github.com/slack-go/slack v0.15.0
 	go.uber.org/mock v0.5.0
+	golang.org/x/time v0.3.0
 )
 
 require (
@@ -0,0 +1,151 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/slack-go/slack"
+)
+
+var _ APIClient = &slackClient{}
+
+// slackClient is the APIClient backed by the Slack Web API.
+// It adds the admin.* methods that slack-go doesn't provide.
+type slackClient struct {
+	*slack.Client
+	token      string
+	apiURL     string
+	httpClient *http.Client
+}
+
+// newSlackClient returns the client calling the Web API at apiURL, which ends with a slash, through httpClient.
+func newSlackClient(token, apiURL string, httpClient *http.Client) *slackClient {
+	return &slackClient{
+		Client:     slack.New(token, slack.OptionAPIURL(apiURL), slack.OptionHTTPClient(httpClient)),
+		token:      token,
+		apiURL:     apiURL,
+		httpClient: httpClient,
+	}
+}
+
+func (c *slackClient) AdminConversationsConvertToPrivateContext(ctx context.Context, channelID string) error {
+	return c.postForm(ctx, "admin.conversations.convertToPrivate", url.Values{
+		"channel_id": {channelID},
+	})
+}
+
+func (c *slackClient) AdminConversationsConvertToPublicContext(ctx context.Context, channelID string) error {
+	return c.postForm(ctx, "admin.conversations.convertToPublic", url.Values{
+		"channel_id": {channelID},
+	})
+}
+
+func (c *slackClient) AdminConversationsDeleteContext(ctx context.Context, channelID string) error {
+	return c.postForm(ctx, "admin.conversations.delete", url.Values{
+		"channel_id": {channelID},
+	})
+}
+
+// grantedScopes calls auth.test and returns the scopes granted to the token, as listed in the X-OAuth-Scopes header.
+// It returns nil when Slack doesn't list them, e.g. for legacy tokens.
+func (c *slackClient) grantedScopes(ctx context.Context) ([]string, error) {
+	header, err := c.post(ctx, "auth.test", url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	if header.Get("X-OAuth-Scopes") == "" {
+		return nil, nil
+	}
+	var scopes []string
+	for _, scope := range strings.Split(header.Get("X-OAuth-Scopes"), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+// tokenClients returns the client of each kind of token and the scopes granted to them.
+// auth.test is called once for each distinct token, through retrying so that it is rate limited and retried like any other call.
+func tokenClients(
+	ctx context.Context, tokens map[tokenKind]string, apiURL string, httpClient *http.Client, retrying *retryingClient,
+) (map[tokenKind]APIClient, grantedScopes, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	kinds := make([]tokenKind, 0, len(tokens))
+	for kind := range tokens {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+
+	clients := make(map[tokenKind]APIClient, len(tokens))
+	scopes := make(grantedScopes, len(tokens))
+	tokenScopes := make(map[string][]string, len(tokens))
+	for _, kind := range kinds {
+		token := tokens[kind]
+		slackClient := newSlackClient(token, apiURL, httpClient)
+		granted, ok := tokenScopes[token]
+		if !ok {
+			var err error
+			granted, err = retryValue(ctx, retrying, "auth.test", func() ([]string, error) {
+				return slackClient.grantedScopes(ctx)
+			})
+			if err != nil {
+				addSlackError(&diags, fmt.Sprintf("failed to check the scopes of the %s token", kind), err)
+				return nil, nil, diags
+			}
+			tokenScopes[token] = granted
+		}
+		if granted != nil {
+			scopes[kind] = granted
+		}
+		clients[kind] = slackClient
+	}
+	return clients, scopes, diags
+}
+
+// postForm calls a Web API method and reports failures the same way slack-go does.
+func (c *slackClient) postForm(ctx context.Context, method string, values url.Values) error {
+	_, err := c.post(ctx, method, values)
+	return err
+}
+
+// post calls a Web API method and returns the headers of its response.
+func (c *slackClient) post(ctx context.Context, method string, values url.Values) (http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+method, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter, err := strconv.ParseInt(resp.Header.Get("Retry-After"), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &slack.RateLimitedError{RetryAfter: time.Duration(retryAfter) * time.Second}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, slack.StatusCodeError{Code: resp.StatusCode, Status: resp.Status}
+	}
+
+	var slackResp slack.SlackResponse
+	if err := json.NewDecoder(resp.Body).Decode(&slackResp); err != nil {
+		return nil, err
+	}
+	return resp.Header, slackResp.Err()
+}
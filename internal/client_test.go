@@ -0,0 +1,216 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+func TestSlackClientAdminConversations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		handler        http.HandlerFunc
+		wantErr        string
+		wantRetryAfter time.Duration
+	}{
+		{
+			name: "ok",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/admin.conversations.convertToPrivate" || r.FormValue("channel_id") != "C123" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				if r.Header.Get("Authorization") != "Bearer token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				_, _ = w.Write([]byte(`{"ok":true}`))
+			},
+		},
+		{
+			name: "slack error",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"ok":false,"error":"restricted_action"}`))
+			},
+			wantErr: "restricted_action",
+		},
+		{
+			name: "rate limited",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Retry-After", "3")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			wantRetryAfter: 3 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			client := newSlackClient("token", server.URL+"/", server.Client())
+
+			err := client.AdminConversationsConvertToPrivateContext(context.Background(), "C123")
+			var rateLimitedErr *slack.RateLimitedError
+			switch {
+			case tt.wantErr != "":
+				if !isSlackError(err, tt.wantErr) {
+					t.Errorf("got %v, want %s", err, tt.wantErr)
+				}
+			case tt.wantRetryAfter != 0:
+				if !errors.As(err, &rateLimitedErr) || rateLimitedErr.RetryAfter != tt.wantRetryAfter {
+					t.Errorf("got %v, want retry after %s", err, tt.wantRetryAfter)
+				}
+			case err != nil:
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSlackClientGrantedScopes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantScopes []string
+		wantErr    string
+	}{
+		{
+			name: "scopes",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/auth.test" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("X-OAuth-Scopes", "channels:read, channels:manage,groups:read")
+				_, _ = w.Write([]byte(`{"ok":true}`))
+			},
+			wantScopes: []string{"channels:read", "channels:manage", "groups:read"},
+		},
+		{
+			name: "no scopes header",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"ok":true}`))
+			},
+		},
+		{
+			name: "invalid token",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+			},
+			wantErr: "invalid_auth",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			client := newSlackClient("token", server.URL+"/", server.Client())
+
+			scopes, err := client.grantedScopes(context.Background())
+			if tt.wantErr != "" {
+				if !isSlackError(err, tt.wantErr) {
+					t.Errorf("got %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if !slices.Equal(scopes, tt.wantScopes) {
+				t.Errorf("got scopes %v, want %v", scopes, tt.wantScopes)
+			}
+		})
+	}
+}
+
+func TestSlackClientAPIURL(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client := newSlackClient("token", server.URL+"/api/", server.Client())
+	ctx := context.Background()
+	if err := client.ArchiveConversationContext(ctx, "C123"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := client.AdminConversationsDeleteContext(ctx, "C123"); err != nil {
+		t.Error(err)
+		return
+	}
+	if want := []string{"/api/conversations.archive", "/api/admin.conversations.delete"}; !slices.Equal(paths, want) {
+		t.Errorf("got paths %v, want %v", paths, want)
+	}
+}
+
+func TestTokenClients(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		token := r.Header.Get("Authorization")
+		calls[token]++
+		// Every token is rate limited once, which is retried.
+		if calls[token] == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("X-OAuth-Scopes", "scope-of-"+strings.TrimPrefix(token, "Bearer "))
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	tokens := map[tokenKind]string{
+		tokenKindBot:   "shared",
+		tokenKindUser:  "shared",
+		tokenKindAdmin: "admin",
+	}
+	retrying := newRetryingClient(nil, newMethodLimiter(map[string]int{"auth.test": 6000}), 1, time.Second)
+	clients, scopes, diags := tokenClients(context.Background(), tokens, server.URL+"/", server.Client(), retrying)
+	if diags.HasError() {
+		t.Error(diags)
+		return
+	}
+
+	if len(clients) != 3 {
+		t.Errorf("got %d clients, want 3", len(clients))
+	}
+	for kind, want := range map[tokenKind]string{tokenKindBot: "scope-of-shared", tokenKindUser: "scope-of-shared", tokenKindAdmin: "scope-of-admin"} {
+		if !slices.Equal(scopes[kind], []string{want}) {
+			t.Errorf("got %s scopes %v, want [%s]", kind, scopes[kind], want)
+		}
+	}
+	// auth.test is called once per distinct token, plus the retry.
+	if want := map[string]int{"Bearer shared": 2, "Bearer admin": 2}; !maps.Equal(calls, want) {
+		t.Errorf("got auth.test calls %v, want %v", calls, want)
+	}
+}
@@ -3,10 +3,12 @@ package internal
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/slack-go/slack"
 )
@@ -17,7 +19,11 @@ var (
 )
 
 type DataSourceConversation struct {
-	client APIClient
+	client          APIClient
+	membersPageSize int
+	tokens          tokenKinds
+	scopes          grantedScopes
+	teamID          string
 }
 
 type DataSourceConversationState struct {
@@ -33,6 +39,7 @@ type DataSourceConversationState struct {
 	ConnectedTeamIDs types.List           `tfsdk:"connected_team_ids"`
 	SharedTeamIDs    types.List           `tfsdk:"shared_team_ids"`
 	InternalTeamIDs  types.List           `tfsdk:"internal_team_ids"`
+	TeamID           types.String         `tfsdk:"team_id"`
 }
 
 type ConversationTopic struct {
@@ -113,6 +120,11 @@ func (d *DataSourceConversation) Schema(_ context.Context, _ datasource.SchemaRe
 				ElementType: types.StringType,
 				Computed:    true,
 			},
+			"team_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The workspace the conversation is looked up in. Defaults to the team_id of the provider.",
+			},
 		},
 	}
 }
@@ -121,10 +133,20 @@ func (d *DataSourceConversation) Configure(ctx context.Context, req datasource.C
 	if req.ProviderData == nil {
 		return
 	}
-	d.client = req.ProviderData.(APIClient)
+	data := req.ProviderData.(*providerData)
+	d.client = data.client
+	d.tokens = data.tokens
+	d.scopes = data.scopes
+	d.teamID = data.teamID
+	d.membersPageSize = data.membersPageSize
 }
 
 func (d *DataSourceConversation) Read(ctx context.Context, req datasource.ReadRequest, res *datasource.ReadResponse) {
+	d.tokens.require(&res.Diagnostics, tokenKindBot, "data.slack_conversation")
+	d.scopes.require(&res.Diagnostics, tokenKindBot, "data.slack_conversation", "channels:read", "groups:read")
+	if res.Diagnostics.HasError() {
+		return
+	}
 	var state DataSourceConversationState
 	diags := req.Config.Get(ctx, &state)
 	res.Diagnostics.Append(diags...)
@@ -135,20 +157,23 @@ func (d *DataSourceConversation) Read(ctx context.Context, req datasource.ReadRe
 		ChannelID: state.ID.ValueString(),
 	})
 	if err != nil {
-		res.Diagnostics.AddError(
-			fmt.Sprintf("the conversation with the id %s does not exist", state.ID.String()),
-			err.Error(),
+		addSlackAttributeError(&res.Diagnostics, errorAttributes{conversation: path.Root("id")},
+			fmt.Sprintf("the conversation with the id %s does not exist", state.ID.String()), err)
+		return
+	}
+	// conversations.info looks conversations up in the whole org, so make sure it belongs to the workspace.
+	teamID := teamIDOrDefault(state.TeamID, d.teamID)
+	if teamID != "" && !conversationInTeam(channel, teamID) {
+		res.Diagnostics.AddAttributeError(
+			path.Root("team_id"),
+			fmt.Sprintf("the conversation with the id %s isn't in the workspace %s", state.ID.ValueString(), teamID),
+			"",
 		)
 		return
 	}
-	users, _, err := d.client.GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
-		ChannelID: state.ID.ValueString(),
-	})
+	users, err := getConversationMembers(ctx, d.client, state.ID.ValueString(), d.membersPageSize)
 	if err != nil {
-		res.Diagnostics.AddError(
-			fmt.Sprintf("failed to get users in conversation with id %s", state.ID.String()),
-			err.Error(),
-		)
+		addSlackError(&res.Diagnostics, fmt.Sprintf("failed to get users in conversation with id %s", state.ID.String()), err)
 		return
 	}
 
@@ -211,7 +236,15 @@ func (d *DataSourceConversation) Read(ctx context.Context, req datasource.ReadRe
 		ConnectedTeamIDs: connectedTeamIDList,
 		SharedTeamIDs:    sharedTeamIDList,
 		InternalTeamIDs:  internalTeamIDList,
+		TeamID:           types.StringValue(teamID),
 	}
 	diags = res.State.Set(ctx, state)
 	res.Diagnostics.Append(diags...)
 }
+
+// conversationInTeam reports whether the conversation belongs to or is shared with the team.
+// Conversations outside of an Enterprise Grid org don't list their teams, so they always do.
+func conversationInTeam(channel *slack.Channel, teamID string) bool {
+	teams := slices.Concat(channel.InternalTeamIDs, channel.SharedTeamIDs, channel.ConnectedTeamIDs)
+	return len(teams) == 0 || slices.Contains(teams, teamID)
+}
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -36,16 +37,21 @@ func TestAccDataSourceConversation(t *testing.T) {
 		},
 	}
 
-	usersResp := []string{"test"}
+	usersResp := []string{"test", "test2", "test3"}
 
 	ctrl := gomock.NewController(t)
 	client := mock.NewMockAPIClient(ctrl)
 	client.EXPECT().GetConversationInfoContext(gomock.Any(), &slack.GetConversationInfoInput{
 		ChannelID: "test",
 	}).Return(conversationInfoResp, nil).AnyTimes()
-	client.EXPECT().GetUsersInConversationContext(gomock.Any(), &slack.GetUsersInConversationParameters{
-		ChannelID: "test",
-	}).Return(usersResp, "", nil).AnyTimes()
+	client.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+			if params.ChannelID != "test" {
+				return nil, "", slack.SlackErrorResponse{Err: "channel_not_found"}
+			}
+			return membersPage(usersResp, params)
+		},
+	).AnyTimes()
 
 	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
@@ -57,7 +63,9 @@ func TestAccDataSourceConversation(t *testing.T) {
 					resource.TestCheckResourceAttr("data.slack_conversation.test", "name", "test"),
 					resource.TestCheckResourceAttr("data.slack_conversation.test", "creator", "test"),
 					resource.TestCheckResourceAttr("data.slack_conversation.test", "is_archived", "false"),
+					resource.TestCheckResourceAttr("data.slack_conversation.test", "members.#", "3"),
 					resource.TestCheckResourceAttr("data.slack_conversation.test", "members.0", "test"),
+					resource.TestCheckResourceAttr("data.slack_conversation.test", "members.2", "test3"),
 					resource.TestCheckResourceAttr("data.slack_conversation.test", "topic.value", "test"),
 					resource.TestCheckResourceAttr("data.slack_conversation.test", "topic.creator", "test"),
 					resource.TestCheckResourceAttr("data.slack_conversation.test", "purpose.value", "test"),
@@ -74,7 +82,7 @@ func TestAccDataSourceConversation(t *testing.T) {
 }
 
 func testAccDataSourceConversation() string {
-	return providerConfig + `
+	return providerConfigWithMembersPageSize(2) + `
 data "slack_conversation" "test" {
 	id = "test"
 }`
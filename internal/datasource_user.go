@@ -1,11 +1,14 @@
 package internal
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -16,6 +19,9 @@ var (
 
 type DataSourceUser struct {
 	client APIClient
+	tokens tokenKinds
+	scopes grantedScopes
+	teamID string
 }
 
 type DataSourceUserState struct {
@@ -59,7 +65,9 @@ func (d *DataSourceUser) Schema(_ context.Context, _ datasource.SchemaRequest, r
 				Required: true,
 			},
 			"team_id": schema.StringAttribute{
-				Computed: true,
+				Optional:    true,
+				Computed:    true,
+				Description: "The workspace the user is looked up in. Defaults to the team_id of the provider, or else to the workspace of the user.",
 			},
 			"name": schema.StringAttribute{
 				Computed: true,
@@ -122,10 +130,19 @@ func (d *DataSourceUser) Configure(ctx context.Context, req datasource.Configure
 	if req.ProviderData == nil {
 		return
 	}
-	d.client = req.ProviderData.(APIClient)
+	data := req.ProviderData.(*providerData)
+	d.client = data.client
+	d.tokens = data.tokens
+	d.scopes = data.scopes
+	d.teamID = data.teamID
 }
 
 func (d *DataSourceUser) Read(ctx context.Context, req datasource.ReadRequest, res *datasource.ReadResponse) {
+	d.tokens.require(&res.Diagnostics, tokenKindBot, "data.slack_user")
+	d.scopes.require(&res.Diagnostics, tokenKindBot, "data.slack_user", "users:read.email")
+	if res.Diagnostics.HasError() {
+		return
+	}
 	var state DataSourceUserState
 	diags := req.Config.Get(ctx, &state)
 	res.Diagnostics.Append(diags...)
@@ -134,15 +151,24 @@ func (d *DataSourceUser) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 	user, err := d.client.GetUserByEmailContext(ctx, state.Email.ValueString())
 	if err != nil {
-		res.Diagnostics.AddError(
-			fmt.Sprintf("the user that has the email %s does not exist", state.Email.String()),
-			err.Error(),
+		addSlackAttributeError(&res.Diagnostics, errorAttributes{users: path.Root("email")},
+			fmt.Sprintf("the user that has the email %s does not exist", state.Email.String()), err)
+		return
+	}
+	// users.lookupByEmail searches the whole org, so make sure the user belongs to the workspace.
+	teamID := teamIDOrDefault(state.TeamID, d.teamID)
+	if teamID != "" && user.TeamID != teamID && !slices.Contains(user.Enterprise.Teams, teamID) {
+		res.Diagnostics.AddAttributeError(
+			path.Root("team_id"),
+			fmt.Sprintf("the user that has the email %s isn't a member of the workspace %s", state.Email.ValueString(), teamID),
+			"",
 		)
+		return
 	}
 	state = DataSourceUserState{
 		ID:                types.StringValue(user.ID),
 		Email:             types.StringValue(user.Profile.Email),
-		TeamID:            types.StringValue(user.TeamID),
+		TeamID:            types.StringValue(cmp.Or(teamID, user.TeamID)),
 		Name:              types.StringValue(user.Name),
 		Delete:            types.BoolValue(user.Deleted),
 		RealName:          types.StringValue(user.RealName),
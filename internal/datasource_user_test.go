@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -78,3 +80,45 @@ data "slack_user" "test" {
     email = "test@example.com"
 }`
 }
+
+func TestAccDataSourceUserTeamID(t *testing.T) {
+	t.Parallel()
+
+	resp := &slack.User{
+		ID:     "U123",
+		TeamID: "T1",
+		Enterprise: slack.EnterpriseUser{
+			Teams: []string{"T1", "T2"},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+	client.EXPECT().GetUserByEmailContext(gomock.Any(), "test@example.com").Return(resp, nil).AnyTimes()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceUserTeamID("T2"),
+				Check:  resource.TestCheckResourceAttr("data.slack_user.test", "team_id", "T2"),
+			},
+			{
+				Config:      testAccDataSourceUserTeamID("T3"),
+				ExpectError: regexp.MustCompile(`isn't a member of the workspace T3`),
+			},
+		},
+	})
+}
+
+func testAccDataSourceUserTeamID(teamID string) string {
+	return fmt.Sprintf(`
+provider "slack" {
+	token = "test"
+	team_id = %q
+}
+
+data "slack_user" "test" {
+	email = "test@example.com"
+}`, teamID)
+}
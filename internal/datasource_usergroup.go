@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"math/big"
@@ -8,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/slack-go/slack"
 )
@@ -19,6 +21,9 @@ var (
 
 type DataSourceUserGroup struct {
 	client APIClient
+	tokens tokenKinds
+	scopes grantedScopes
+	teamID string
 }
 
 type DataSourceUserGroupState struct {
@@ -58,7 +63,9 @@ func (d *DataSourceUserGroup) Schema(_ context.Context, _ datasource.SchemaReque
 				Required: true,
 			},
 			"team_id": schema.StringAttribute{
-				Computed: true,
+				Optional:    true,
+				Computed:    true,
+				Description: "The workspace the usergroup is looked up in. Defaults to the team_id of the provider.",
 			},
 			"is_user_group": schema.BoolAttribute{
 				Computed: true,
@@ -115,26 +122,39 @@ func (d *DataSourceUserGroup) Configure(ctx context.Context, req datasource.Conf
 	if req.ProviderData == nil {
 		return
 	}
-	d.client = req.ProviderData.(APIClient)
+	data := req.ProviderData.(*providerData)
+	d.client = data.client
+	d.tokens = data.tokens
+	d.scopes = data.scopes
+	d.teamID = data.teamID
 }
 
 func (d *DataSourceUserGroup) Read(ctx context.Context, req datasource.ReadRequest, res *datasource.ReadResponse) {
+	d.tokens.require(&res.Diagnostics, tokenKindUser, "data.slack_usergroup")
+	d.scopes.require(&res.Diagnostics, tokenKindUser, "data.slack_usergroup", "usergroups:read")
+	if res.Diagnostics.HasError() {
+		return
+	}
 	var state DataSourceUserGroupState
 	diags := req.Config.Get(ctx, &state)
 	res.Diagnostics.Append(diags...)
 	if res.Diagnostics.HasError() {
 		return
 	}
-	userGroups, err := d.client.GetUserGroupsContext(ctx,
+	opts := []slack.GetUserGroupsOption{
 		slack.GetUserGroupsOptionIncludeCount(true),
 		slack.GetUserGroupsOptionIncludeUsers(true),
 		slack.GetUserGroupsOptionIncludeDisabled(true),
-	)
+	}
+	teamID := teamIDOrDefault(state.TeamID, d.teamID)
+	if teamID != "" {
+		opts = append(opts, slack.GetUserGroupsOptionWithTeamID(teamID))
+	}
+	userGroups, err := d.client.GetUserGroupsContext(ctx, opts...)
 	if err != nil {
-		res.Diagnostics.AddError(
-			fmt.Sprintf("the usergroup that has the id %s does not exist", state.TeamID.String()),
-			err.Error(),
-		)
+		addSlackAttributeError(&res.Diagnostics, errorAttributes{userGroup: path.Root("id")},
+			fmt.Sprintf("failed to read the usergroup with the id %s", state.ID.ValueString()), err)
+		return
 	}
 	var userGroup slack.UserGroup
 	for _, ug := range userGroups {
@@ -149,6 +169,7 @@ func (d *DataSourceUserGroup) Read(ctx context.Context, req datasource.ReadReque
 			fmt.Sprintf("the usergroup that has the id %s does not exist", state.ID.String()),
 			"",
 		)
+		return
 	}
 
 	channels := make([]attr.Value, 0, len(userGroup.Prefs.Channels))
@@ -183,7 +204,7 @@ func (d *DataSourceUserGroup) Read(ctx context.Context, req datasource.ReadReque
 
 	state = DataSourceUserGroupState{
 		ID:          types.StringValue(userGroup.ID),
-		TeamID:      types.StringValue(userGroup.TeamID),
+		TeamID:      types.StringValue(cmp.Or(userGroup.TeamID, teamID)),
 		IsUserGroup: types.BoolValue(userGroup.IsUserGroup),
 		Name:        types.StringValue(userGroup.Name),
 		Description: types.StringValue(userGroup.Description),
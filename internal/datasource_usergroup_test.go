@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -65,6 +66,24 @@ func TestAccDataSourceUserGroup(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceUserGroupError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+	client.EXPECT().GetUserGroupsContext(gomock.Any(), gomock.Any()).Return(nil, slack.SlackErrorResponse{Err: "missing_scope"}).AnyTimes()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDataSourceUserGroup(),
+				ExpectError: regexp.MustCompile(`(?s)failed to read the usergroup with the id test.*usergroups.list needs the usergroups:read scope`),
+			},
+		},
+	})
+}
+
 func testAccDataSourceUserGroup() string {
 	return providerConfig + `
 data "slack_usergroup" "test" {
@@ -0,0 +1,178 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/slack-go/slack"
+)
+
+// methodError is an error returned by a Slack Web API method, along with the name of that method.
+type methodError struct {
+	method string
+	err    error
+}
+
+func (e *methodError) Error() string { return e.err.Error() }
+
+func (e *methodError) Unwrap() error { return e.err }
+
+// methodScopes are the OAuth scopes each Web API method accepts, any one of which is enough.
+// Bot tokens manage public channels with channels:manage, user tokens with channels:write.
+var methodScopes = map[string][]string{
+	"users.lookupByEmail":                  {"users:read.email"},
+	"usergroups.list":                      {"usergroups:read"},
+	"usergroups.users.list":                {"usergroups:read"},
+	"usergroups.create":                    {"usergroups:write"},
+	"usergroups.update":                    {"usergroups:write"},
+	"usergroups.enable":                    {"usergroups:write"},
+	"usergroups.disable":                   {"usergroups:write"},
+	"usergroups.users.update":              {"usergroups:write"},
+	"conversations.info":                   {"channels:read", "groups:read"},
+	"conversations.list":                   {"channels:read", "groups:read"},
+	"conversations.members":                {"channels:read", "groups:read"},
+	"conversations.create":                 {"channels:manage", "channels:write", "groups:write"},
+	"conversations.rename":                 {"channels:manage", "channels:write", "groups:write"},
+	"conversations.setTopic":               {"channels:manage", "channels:write", "groups:write"},
+	"conversations.setPurpose":             {"channels:manage", "channels:write", "groups:write"},
+	"conversations.invite":                 {"channels:manage", "channels:write", "groups:write"},
+	"conversations.kick":                   {"channels:manage", "channels:write", "groups:write"},
+	"conversations.leave":                  {"channels:manage", "channels:write", "groups:write"},
+	"conversations.close":                  {"channels:manage", "channels:write", "groups:write"},
+	"conversations.archive":                {"channels:manage", "channels:write", "groups:write"},
+	"conversations.unarchive":              {"channels:manage", "channels:write", "groups:write"},
+	"admin.conversations.delete":           {"admin.conversations:write"},
+	"admin.conversations.convertToPrivate": {"admin.conversations:write"},
+	"admin.conversations.convertToPublic":  {"admin.conversations:write"},
+}
+
+// errorAttributes are the attributes holding the values a Slack call can reject, by what the values refer to.
+// The attributes a call doesn't involve are left empty.
+type errorAttributes struct {
+	name         path.Path
+	handle       path.Path
+	conversation path.Path
+	users        path.Path
+	userGroup    path.Path
+}
+
+// attribute returns the attribute holding the value rejected with the given kind of error, if any.
+func (a errorAttributes) attribute(kind errorKind) (path.Path, bool) {
+	var p path.Path
+	switch kind {
+	case errorKindName:
+		p = a.name
+	case errorKindHandle:
+		p = a.handle
+	case errorKindConversation:
+		p = a.conversation
+	case errorKindUsers:
+		p = a.users
+	case errorKindUserGroup:
+		p = a.userGroup
+	}
+	return p, len(p.Steps()) > 0
+}
+
+// errorKind is what a Slack error rejects.
+type errorKind int
+
+const (
+	errorKindNone errorKind = iota
+	errorKindName
+	errorKindHandle
+	errorKindConversation
+	errorKindUsers
+	errorKindUserGroup
+)
+
+// slackErrorHints explain how to fix the Slack errors that can be acted on.
+var slackErrorHints = map[string]struct {
+	hint string
+	kind errorKind
+}{
+	"name_taken": {
+		hint: "Another object in Slack already holds this name. Archived conversations keep their names too. " +
+			"Pick another name, or set on_name_conflict to adopt an existing conversation.",
+		kind: errorKindName,
+	},
+	"name_already_exists": {
+		hint: "Another user group already holds this name. Disabled user groups keep their names too. " +
+			"Pick another name, or enable and import the existing user group.",
+		kind: errorKindName,
+	},
+	"handle_already_exists": {
+		hint: "Another user group already holds this handle. Disabled user groups keep their handles too. " +
+			"Pick another handle, or enable and import the existing user group.",
+		kind: errorKindHandle,
+	},
+	"channel_not_found": {
+		hint: "The conversation doesn't exist or isn't visible to the token. Private conversations are only visible to their members, so invite the Slack app to it.",
+		kind: errorKindConversation,
+	},
+	"is_archived": {
+		hint: "The conversation is archived. Unarchive it in Slack, or remove it from state so that Terraform creates a new one.",
+		kind: errorKindConversation,
+	},
+	"user_not_found": {
+		hint: "A user id doesn't match any user of the workspace. User ids start with U or W.",
+		kind: errorKindUsers,
+	},
+	"users_not_found": {
+		hint: "No user of the workspace has this email address, or the token isn't allowed to see it.",
+		kind: errorKindUsers,
+	},
+	"cant_kick_self": {
+		hint: "The user of the token can't remove itself from the conversation. Keep it in the members.",
+		kind: errorKindUsers,
+	},
+	"no_such_subteam": {
+		hint: "The user group doesn't exist. Check the id, or remove it from state if it was deleted outside of Terraform.",
+		kind: errorKindUserGroup,
+	},
+	"restricted_action": {
+		hint: "A workspace setting or the role of the token's user forbids this. Ask a workspace admin to allow it, or use the token of a user who is allowed to.",
+	},
+	"missing_scope": {
+		hint: "The token wasn't granted a scope this call needs. Add the scope to the Slack app and reinstall the app.",
+	},
+}
+
+// addSlackError adds err to diags under summary, along with a hint when err is a Slack error that can be acted on.
+func addSlackError(diags *diag.Diagnostics, summary string, err error) {
+	addSlackAttributeError(diags, errorAttributes{}, summary, err)
+}
+
+// addSlackAttributeError is addSlackError for a call made with the values of attributes.
+// The error is reported on the attribute holding the value Slack rejected, if any.
+func addSlackAttributeError(diags *diag.Diagnostics, attributes errorAttributes, summary string, err error) {
+	detail, kind := slackErrorDetail(err)
+	if p, ok := attributes.attribute(kind); ok {
+		diags.AddAttributeError(p, summary, detail)
+		return
+	}
+	diags.AddError(summary, detail)
+}
+
+// slackErrorDetail returns the detail of the diagnostic for err and what err rejects.
+func slackErrorDetail(err error) (string, errorKind) {
+	var slackErr slack.SlackErrorResponse
+	if !errors.As(err, &slackErr) {
+		return err.Error(), errorKindNone
+	}
+	hint, ok := slackErrorHints[slackErr.Err]
+	if !ok {
+		return err.Error(), errorKindNone
+	}
+
+	detail := hint.hint
+	var methodErr *methodError
+	if slackErr.Err == "missing_scope" && errors.As(err, &methodErr) && len(methodScopes[methodErr.method]) > 0 {
+		detail = fmt.Sprintf("%s needs the %s scope, which the token wasn't granted. Add it to the Slack app and reinstall the app.",
+			methodErr.method, strings.Join(methodScopes[methodErr.method], " or "))
+	}
+	return err.Error() + "\n\n" + detail, hint.kind
+}
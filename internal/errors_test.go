@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/slack-go/slack"
+)
+
+func TestAddSlackAttributeError(t *testing.T) {
+	t.Parallel()
+
+	attributes := errorAttributes{
+		conversation: path.Root("channel_id"),
+		users:        path.Root("user_id"),
+	}
+
+	tests := map[string]struct {
+		err           error
+		wantAttribute path.Path
+		wantDetail    string
+	}{
+		"rejected user": {
+			err:           &methodError{method: "conversations.invite", err: slack.SlackErrorResponse{Err: "user_not_found"}},
+			wantAttribute: path.Root("user_id"),
+			wantDetail:    "User ids start with U or W.",
+		},
+		"rejected conversation": {
+			err:           &methodError{method: "conversations.invite", err: slack.SlackErrorResponse{Err: "is_archived"}},
+			wantAttribute: path.Root("channel_id"),
+			wantDetail:    "The conversation is archived.",
+		},
+		"value the call doesn't involve": {
+			err:        slack.SlackErrorResponse{Err: "name_taken"},
+			wantDetail: "Another object in Slack already holds this name.",
+		},
+		"missing scope": {
+			err:        &methodError{method: "conversations.invite", err: slack.SlackErrorResponse{Err: "missing_scope"}},
+			wantDetail: "conversations.invite needs the channels:manage or channels:write or groups:write scope",
+		},
+		"missing scope of an unknown method": {
+			err:        slack.SlackErrorResponse{Err: "missing_scope"},
+			wantDetail: "The token wasn't granted a scope this call needs.",
+		},
+		"unknown slack error": {
+			err:        slack.SlackErrorResponse{Err: "fatal_error"},
+			wantDetail: "fatal_error",
+		},
+		"other error": {
+			err:        errors.New("connection refused"),
+			wantDetail: "connection refused",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			addSlackAttributeError(&diags, attributes, "failed", tt.err)
+			if len(diags) != 1 {
+				t.Errorf("got %d diagnostics, want 1", len(diags))
+				return
+			}
+			var gotAttribute path.Path
+			if d, ok := diags[0].(diag.DiagnosticWithPath); ok {
+				gotAttribute = d.Path()
+			}
+			if !gotAttribute.Equal(tt.wantAttribute) {
+				t.Errorf("got attribute %s, want %s", gotAttribute, tt.wantAttribute)
+			}
+			if detail := diags[0].Detail(); !strings.HasPrefix(detail, tt.err.Error()) || !strings.Contains(detail, tt.wantDetail) {
+				t.Errorf("got detail %q, want the error followed by %q", detail, tt.wantDetail)
+			}
+		})
+	}
+}
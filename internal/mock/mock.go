@@ -40,6 +40,48 @@ func (m *MockAPIClient) EXPECT() *MockAPIClientMockRecorder {
 	return m.recorder
 }
 
+// AdminConversationsConvertToPrivateContext mocks base method.
+func (m *MockAPIClient) AdminConversationsConvertToPrivateContext(ctx context.Context, channelID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminConversationsConvertToPrivateContext", ctx, channelID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminConversationsConvertToPrivateContext indicates an expected call of AdminConversationsConvertToPrivateContext.
+func (mr *MockAPIClientMockRecorder) AdminConversationsConvertToPrivateContext(ctx, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminConversationsConvertToPrivateContext", reflect.TypeOf((*MockAPIClient)(nil).AdminConversationsConvertToPrivateContext), ctx, channelID)
+}
+
+// AdminConversationsConvertToPublicContext mocks base method.
+func (m *MockAPIClient) AdminConversationsConvertToPublicContext(ctx context.Context, channelID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminConversationsConvertToPublicContext", ctx, channelID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminConversationsConvertToPublicContext indicates an expected call of AdminConversationsConvertToPublicContext.
+func (mr *MockAPIClientMockRecorder) AdminConversationsConvertToPublicContext(ctx, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminConversationsConvertToPublicContext", reflect.TypeOf((*MockAPIClient)(nil).AdminConversationsConvertToPublicContext), ctx, channelID)
+}
+
+// AdminConversationsDeleteContext mocks base method.
+func (m *MockAPIClient) AdminConversationsDeleteContext(ctx context.Context, channelID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminConversationsDeleteContext", ctx, channelID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminConversationsDeleteContext indicates an expected call of AdminConversationsDeleteContext.
+func (mr *MockAPIClientMockRecorder) AdminConversationsDeleteContext(ctx, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminConversationsDeleteContext", reflect.TypeOf((*MockAPIClient)(nil).AdminConversationsDeleteContext), ctx, channelID)
+}
+
 // ArchiveConversationContext mocks base method.
 func (m *MockAPIClient) ArchiveConversationContext(ctx context.Context, channelID string) error {
 	m.ctrl.T.Helper()
@@ -145,6 +187,22 @@ func (mr *MockAPIClientMockRecorder) GetConversationInfoContext(ctx, input any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversationInfoContext", reflect.TypeOf((*MockAPIClient)(nil).GetConversationInfoContext), ctx, input)
 }
 
+// GetConversationsContext mocks base method.
+func (m *MockAPIClient) GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversationsContext", ctx, params)
+	ret0, _ := ret[0].([]slack.Channel)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetConversationsContext indicates an expected call of GetConversationsContext.
+func (mr *MockAPIClientMockRecorder) GetConversationsContext(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversationsContext", reflect.TypeOf((*MockAPIClient)(nil).GetConversationsContext), ctx, params)
+}
+
 // GetUserByEmailContext mocks base method.
 func (m *MockAPIClient) GetUserByEmailContext(ctx context.Context, email string) (*slack.User, error) {
 	m.ctrl.T.Helper()
@@ -160,6 +218,21 @@ func (mr *MockAPIClientMockRecorder) GetUserByEmailContext(ctx, email any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmailContext", reflect.TypeOf((*MockAPIClient)(nil).GetUserByEmailContext), ctx, email)
 }
 
+// GetUserGroupMembersContext mocks base method.
+func (m *MockAPIClient) GetUserGroupMembersContext(ctx context.Context, userGroup string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserGroupMembersContext", ctx, userGroup)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserGroupMembersContext indicates an expected call of GetUserGroupMembersContext.
+func (mr *MockAPIClientMockRecorder) GetUserGroupMembersContext(ctx, userGroup any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserGroupMembersContext", reflect.TypeOf((*MockAPIClient)(nil).GetUserGroupMembersContext), ctx, userGroup)
+}
+
 // GetUserGroupsContext mocks base method.
 func (m *MockAPIClient) GetUserGroupsContext(ctx context.Context, opts ...slack.GetUserGroupsOption) ([]slack.UserGroup, error) {
 	m.ctrl.T.Helper()
@@ -230,6 +303,36 @@ func (mr *MockAPIClientMockRecorder) KickUserFromConversationContext(ctx, channe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KickUserFromConversationContext", reflect.TypeOf((*MockAPIClient)(nil).KickUserFromConversationContext), ctx, channelID, user)
 }
 
+// LeaveConversationContext mocks base method.
+func (m *MockAPIClient) LeaveConversationContext(ctx context.Context, channelID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaveConversationContext", ctx, channelID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LeaveConversationContext indicates an expected call of LeaveConversationContext.
+func (mr *MockAPIClientMockRecorder) LeaveConversationContext(ctx, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveConversationContext", reflect.TypeOf((*MockAPIClient)(nil).LeaveConversationContext), ctx, channelID)
+}
+
+// RenameConversationContext mocks base method.
+func (m *MockAPIClient) RenameConversationContext(ctx context.Context, channelID, channelName string) (*slack.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameConversationContext", ctx, channelID, channelName)
+	ret0, _ := ret[0].(*slack.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameConversationContext indicates an expected call of RenameConversationContext.
+func (mr *MockAPIClientMockRecorder) RenameConversationContext(ctx, channelID, channelName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameConversationContext", reflect.TypeOf((*MockAPIClient)(nil).RenameConversationContext), ctx, channelID, channelName)
+}
+
 // SetPurposeOfConversationContext mocks base method.
 func (m *MockAPIClient) SetPurposeOfConversationContext(ctx context.Context, channelID, purpose string) (*slack.Channel, error) {
 	m.ctrl.T.Helper()
@@ -260,6 +363,20 @@ func (mr *MockAPIClientMockRecorder) SetTopicOfConversationContext(ctx, channelI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTopicOfConversationContext", reflect.TypeOf((*MockAPIClient)(nil).SetTopicOfConversationContext), ctx, channelID, topic)
 }
 
+// UnArchiveConversationContext mocks base method.
+func (m *MockAPIClient) UnArchiveConversationContext(ctx context.Context, channelID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnArchiveConversationContext", ctx, channelID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnArchiveConversationContext indicates an expected call of UnArchiveConversationContext.
+func (mr *MockAPIClientMockRecorder) UnArchiveConversationContext(ctx, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnArchiveConversationContext", reflect.TypeOf((*MockAPIClient)(nil).UnArchiveConversationContext), ctx, channelID)
+}
+
 // UpdateUserGroupContext mocks base method.
 func (m *MockAPIClient) UpdateUserGroupContext(ctx context.Context, userGroupID string, opts ...slack.UpdateUserGroupsOption) (slack.UserGroup, error) {
 	m.ctrl.T.Helper()
@@ -4,11 +4,20 @@ package internal
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/slack-go/slack"
@@ -22,27 +31,64 @@ type APIClient interface {
 	CreateUserGroupContext(ctx context.Context, userGroup slack.UserGroup) (slack.UserGroup, error)
 	GetUserGroupsContext(ctx context.Context, opts ...slack.GetUserGroupsOption) ([]slack.UserGroup, error)
 	UpdateUserGroupContext(ctx context.Context, userGroupID string, opts ...slack.UpdateUserGroupsOption) (slack.UserGroup, error)
+	GetUserGroupMembersContext(ctx context.Context, userGroup string) ([]string, error)
 	UpdateUserGroupMembersContext(ctx context.Context, userGroup string, members string) (slack.UserGroup, error)
 	EnableUserGroupContext(ctx context.Context, userGroup string) (slack.UserGroup, error)
 	DisableUserGroupContext(ctx context.Context, userGroup string) (slack.UserGroup, error)
 	// Conversations
 	GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error)
 	GetUsersInConversationContext(ctx context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error)
+	GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error)
 	CreateConversationContext(ctx context.Context, params slack.CreateConversationParams) (*slack.Channel, error)
 	SetTopicOfConversationContext(ctx context.Context, channelID, topic string) (*slack.Channel, error)
 	SetPurposeOfConversationContext(ctx context.Context, channelID, purpose string) (*slack.Channel, error)
+	RenameConversationContext(ctx context.Context, channelID, channelName string) (*slack.Channel, error)
 	InviteUsersToConversationContext(ctx context.Context, channelID string, users ...string) (*slack.Channel, error)
 	KickUserFromConversationContext(ctx context.Context, channelID string, user string) error
 	ArchiveConversationContext(ctx context.Context, channelID string) error
+	UnArchiveConversationContext(ctx context.Context, channelID string) error
 	CloseConversationContext(ctx context.Context, channelID string) (noOp bool, alreadyClosed bool, err error)
+	LeaveConversationContext(ctx context.Context, channelID string) (bool, error)
+	// Admin
+	AdminConversationsConvertToPrivateContext(ctx context.Context, channelID string) error
+	AdminConversationsConvertToPublicContext(ctx context.Context, channelID string) error
+	AdminConversationsDeleteContext(ctx context.Context, channelID string) error
 }
 
+// defaultMembersPageSize is the number of members requested per page from conversations.members.
+const defaultMembersPageSize = 200
+
 type SlackProvider struct {
 	client APIClient
+	// scopes are the scopes granted to client, when it's set.
+	scopes grantedScopes
 }
 
 type SlackProviderConfig struct {
-	Token types.String `tfsdk:"token"`
+	Token           types.String `tfsdk:"token"`
+	TokenFile       types.String `tfsdk:"token_file"`
+	TokenCommand    types.List   `tfsdk:"token_command"`
+	BotToken        types.String `tfsdk:"bot_token"`
+	UserToken       types.String `tfsdk:"user_token"`
+	AdminToken      types.String `tfsdk:"admin_token"`
+	MembersPageSize types.Int64  `tfsdk:"members_page_size"`
+	MaxRetries      types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait    types.Int64  `tfsdk:"retry_max_wait"`
+	RateLimits      types.Map    `tfsdk:"rate_limits"`
+	APIURL          types.String `tfsdk:"api_url"`
+	HTTPProxy       types.String `tfsdk:"http_proxy"`
+	CACertFile      types.String `tfsdk:"ca_cert_file"`
+	RequestTimeout  types.Int64  `tfsdk:"request_timeout"`
+	TeamID          types.String `tfsdk:"team_id"`
+}
+
+// providerData is handed to every resource and data source.
+type providerData struct {
+	client          APIClient
+	tokens          tokenKinds
+	scopes          grantedScopes
+	teamID          string
+	membersPageSize int
 }
 
 func New() func() provider.Provider {
@@ -55,8 +101,92 @@ func (m *SlackProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"token": schema.StringAttribute{
-				Required:  true,
-				Sensitive: true,
+				Optional:    true,
+				Sensitive:   true,
+				Description: fmt.Sprintf("The Slack token. Falls back to the %s environment variable, then to token_file and token_command.", tokenEnvVar),
+			},
+			"bot_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The bot token used for the conversations.* and users.* methods. Defaults to token.",
+			},
+			"user_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The user token used for the usergroups.* methods. Defaults to token.",
+			},
+			"admin_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The org admin user token used for the admin.* methods. Defaults to token.",
+			},
+			"token_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path of a file holding the Slack token.",
+			},
+			"token_command": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "A command, and its arguments, printing the Slack token on stdout, e.g. a credential helper for Vault or 1Password.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"members_page_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The number of conversation members requested per page. Defaults to %d.", defaultMembersPageSize),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 1000),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The number of times a rate limited or transiently failed API call is retried. Defaults to %d.", defaultMaxRetries),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				Optional: true,
+				Description: fmt.Sprintf("The maximum number of seconds to back off between retries of transient errors. "+
+					"The wait requested by Slack for rate limited calls is always honored. Defaults to %d.", int(defaultRetryMaxWait.Seconds())),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"api_url": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The base url of the Slack Web API, e.g. for GovSlack or a stand-in server. Defaults to %s.", slack.APIURL),
+			},
+			"http_proxy": schema.StringAttribute{
+				Optional:    true,
+				Description: "The url of the proxy the Web API is called through. Defaults to the HTTPS_PROXY and HTTP_PROXY environment variables.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path of a PEM file with certificates trusted in addition to the system ones, e.g. the certificate of a TLS intercepting proxy.",
+			},
+			"request_timeout": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The number of seconds a single request to the Web API may take. Defaults to %d.", int(defaultRequestTimeout.Seconds())),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"team_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The workspace of the resources and data sources that don't set team_id. Org-level tokens of an Enterprise Grid org need it.",
+			},
+			"rate_limits": schema.MapAttribute{
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Description: "Overrides the requests per minute allowed by the Slack rate limit tiers, e.g. for Enterprise workspaces. " +
+					"The keys are either a tier, from tier1 to tier4, or a Web API method such as conversations.invite, which takes precedence over its tier.",
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.OneOf(rateLimitKeys()...)),
+					mapvalidator.ValueInt64sAre(int64validator.AtLeast(1)),
+				},
 			},
 		},
 	}
@@ -73,22 +203,85 @@ func (m *SlackProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if resp.Diagnostics.HasError() {
+	token, tokenSource, err := resolveToken(ctx, cfg.Token, cfg.TokenFile, cfg.TokenCommand)
+	tokens := kindTokens(token, cfg.BotToken, cfg.UserToken, cfg.AdminToken)
+	if err != nil && !(errors.Is(err, errNoToken) && len(tokens) > 0) {
+		resp.Diagnostics.AddError("failed to resolve the slack token", err.Error())
 		return
 	}
+	kinds := make(tokenKinds, len(tokens))
+	for kind := range tokens {
+		kinds[kind] = true
+	}
+	maxRetries := defaultMaxRetries
+	if !cfg.MaxRetries.IsNull() {
+		maxRetries = int(cfg.MaxRetries.ValueInt64())
+	}
+	retryMaxWait := defaultRetryMaxWait
+	if !cfg.RetryMaxWait.IsNull() {
+		retryMaxWait = time.Duration(cfg.RetryMaxWait.ValueInt64()) * time.Second
+	}
+	retrying := newRetryingClient(m.client, nil, maxRetries, retryMaxWait)
+	scopes := m.scopes
 	if m.client == nil {
-		client := slack.New(cfg.Token.String())
-		m.client = client
+		var rateLimits map[string]int
+		diags = cfg.RateLimits.ElementsAs(ctx, &rateLimits, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		apiURL := slack.APIURL
+		if cfg.APIURL.ValueString() != "" {
+			apiURL, err = normalizeAPIURL(cfg.APIURL.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("api_url"), "invalid api_url", err.Error())
+				return
+			}
+		}
+		requestTimeout := defaultRequestTimeout
+		if !cfg.RequestTimeout.IsNull() {
+			requestTimeout = time.Duration(cfg.RequestTimeout.ValueInt64()) * time.Second
+		}
+		httpClient, err := newHTTPClient(cfg.HTTPProxy.ValueString(), cfg.CACertFile.ValueString(), requestTimeout)
+		if err != nil {
+			resp.Diagnostics.AddError("failed to configure the http client", err.Error())
+			return
+		}
+		retrying.limiter = newMethodLimiter(rateLimits)
+		var clients map[tokenKind]APIClient
+		clients, scopes, diags = tokenClients(ctx, tokens, apiURL, httpClient, retrying)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		retrying.next = &tokenRouter{clients: clients}
+	}
+	data := &providerData{
+		client:          retrying,
+		tokens:          kinds,
+		scopes:          scopes,
+		teamID:          cfg.TeamID.ValueString(),
+		membersPageSize: defaultMembersPageSize,
+	}
+	if !cfg.MembersPageSize.IsNull() {
+		data.membersPageSize = int(cfg.MembersPageSize.ValueInt64())
 	}
-	resp.DataSourceData = m.client
-	resp.ResourceData = m.client
-	tflog.Info(ctx, "configured slack-provider")
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	tflog.Info(ctx, "configured slack-provider", map[string]any{
+		"token_source": tokenSource,
+		"bot_token":    kinds[tokenKindBot],
+		"user_token":   kinds[tokenKindUser],
+		"admin_token":  kinds[tokenKindAdmin],
+	})
 }
 
 func (m *SlackProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewResourceUserGroup,
 		NewResourceConversation,
+		NewResourceConversationMember,
+		NewResourceUserGroupMember,
 	}
 }
 
@@ -1,8 +1,17 @@
 package internal
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/slack-go/slack"
 )
 
 const (
@@ -12,12 +21,239 @@ provider "slack" {
 }`
 )
 
+// skipUnlessAcc skips an acceptance test that expects calls to be made a given number of times,
+// since gomock would report them missing when resource.Test skips the test itself.
+func skipUnlessAcc(t *testing.T) {
+	t.Helper()
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+}
+
 func protoV6ProviderFactories(client APIClient) map[string]func() (tfprotov6.ProviderServer, error) {
+	return protoV6ProviderFactoriesWithScopes(client, nil)
+}
+
+// protoV6ProviderFactoriesWithScopes returns the provider for client, whose tokens were granted scopes.
+func protoV6ProviderFactoriesWithScopes(client APIClient, scopes grantedScopes) map[string]func() (tfprotov6.ProviderServer, error) {
 	return map[string]func() (tfprotov6.ProviderServer, error){
 		"slack": providerserver.NewProtocol6WithError(
 			&SlackProvider{
 				client: client,
+				scopes: scopes,
 			},
 		),
 	}
 }
+
+// providerConfigWithMembersPageSize returns the provider config listing conversation members size at a time.
+func providerConfigWithMembersPageSize(size int) string {
+	return fmt.Sprintf(`
+provider "slack" {
+	token = "test"
+	members_page_size = %d
+}`, size)
+}
+
+// membersPage returns the page of members requested by params the way conversations.members does,
+// using the offset of the next page as the cursor.
+func membersPage(members []string, params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+	start := 0
+	if params.Cursor != "" {
+		var err error
+		start, err = strconv.Atoi(params.Cursor)
+		if err != nil {
+			return nil, "", slack.SlackErrorResponse{Err: "invalid_cursor"}
+		}
+	}
+	end := min(start+params.Limit, len(members))
+	if params.Limit <= 0 {
+		end = len(members)
+	}
+	if start > end {
+		return nil, "", slack.SlackErrorResponse{Err: "invalid_cursor"}
+	}
+	cursor := ""
+	if end < len(members) {
+		cursor = strconv.Itoa(end)
+	}
+	return append([]string(nil), members[start:end]...), cursor, nil
+}
+
+// upgradeResourceState upgrades the state of a resource of typeName from version, given as JSON,
+// and returns the attributes of the upgraded state.
+func upgradeResourceState(t *testing.T, typeName string, version int64, state string) (map[string]tftypes.Value, bool) {
+	t.Helper()
+
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(&SlackProvider{})()
+	if err != nil {
+		t.Error(err)
+		return nil, false
+	}
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Error(err)
+		return nil, false
+	}
+	res, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: typeName,
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: []byte(state)},
+	})
+	if err != nil {
+		t.Error(err)
+		return nil, false
+	}
+	for _, d := range res.Diagnostics {
+		t.Errorf("%s: %s", d.Summary, d.Detail)
+	}
+	if res.UpgradedState == nil {
+		return nil, false
+	}
+
+	upgraded, err := res.UpgradedState.Unmarshal(schemas.ResourceSchemas[typeName].ValueType())
+	if err != nil {
+		t.Error(err)
+		return nil, false
+	}
+	var attributes map[string]tftypes.Value
+	if err := upgraded.As(&attributes); err != nil {
+		t.Error(err)
+		return nil, false
+	}
+	return attributes, true
+}
+
+// resourceValue returns the value of a resource given as JSON, where missing attributes are null.
+func resourceValue(t *testing.T, typeName, value string) (tftypes.Value, bool) {
+	t.Helper()
+
+	schemas, err := providerserver.NewProtocol6(&SlackProvider{})().GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Error(err)
+		return tftypes.Value{}, false
+	}
+	opts := tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true}
+	v, err := tftypes.ValueFromJSONWithOpts([]byte(value), schemas.ResourceSchemas[typeName].ValueType(), opts)
+	if err != nil {
+		t.Error(err)
+		return tftypes.Value{}, false
+	}
+	return v, true
+}
+
+// planResourceChange plans the change of a resource through the provider server, as Terraform does when it doesn't refresh,
+// and returns the planned state along with the attributes that require replacement.
+// proposed is the new state Terraform proposes, i.e. config along with the computed attributes of prior.
+func planResourceChange(t *testing.T, typeName string, prior, config, proposed tftypes.Value) (tftypes.Value, []*tftypes.AttributePath, bool) {
+	t.Helper()
+
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(&SlackProvider{})()
+	if err != nil {
+		t.Error(err)
+		return tftypes.Value{}, nil, false
+	}
+	dynamicValues := make([]*tfprotov6.DynamicValue, 0, 3)
+	for _, value := range []tftypes.Value{prior, config, proposed} {
+		dv, err := tfprotov6.NewDynamicValue(value.Type(), value)
+		if err != nil {
+			t.Error(err)
+			return tftypes.Value{}, nil, false
+		}
+		dynamicValues = append(dynamicValues, &dv)
+	}
+
+	res, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       dynamicValues[0],
+		Config:           dynamicValues[1],
+		ProposedNewState: dynamicValues[2],
+	})
+	if err != nil {
+		t.Error(err)
+		return tftypes.Value{}, nil, false
+	}
+	for _, d := range res.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+	if res.PlannedState == nil {
+		return tftypes.Value{}, nil, false
+	}
+	planned, err := res.PlannedState.Unmarshal(prior.Type())
+	if err != nil {
+		t.Error(err)
+		return tftypes.Value{}, nil, false
+	}
+	return planned, res.RequiresReplace, true
+}
+
+// expectEmptyPlan checks that planning config over the state of attributes, e.g. just upgraded, changes nothing.
+func expectEmptyPlan(t *testing.T, typeName string, attributes map[string]tftypes.Value, config string) {
+	t.Helper()
+
+	schemas, err := providerserver.NewProtocol6(&SlackProvider{})().GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	schema := schemas.ResourceSchemas[typeName]
+	configValue, ok := resourceValue(t, typeName, config)
+	if !ok {
+		return
+	}
+	var configAttributes map[string]tftypes.Value
+	if err := configValue.As(&configAttributes); err != nil {
+		t.Error(err)
+		return
+	}
+	// Terraform proposes the configured values, and the prior ones of the computed attributes left unset.
+	proposedAttributes := make(map[string]tftypes.Value, len(attributes))
+	for _, attribute := range schema.Block.Attributes {
+		proposedAttributes[attribute.Name] = configAttributes[attribute.Name]
+		if attribute.Computed && configAttributes[attribute.Name].IsNull() {
+			proposedAttributes[attribute.Name] = attributes[attribute.Name]
+		}
+	}
+
+	prior := tftypes.NewValue(schema.ValueType(), attributes)
+	planned, requiresReplace, ok := planResourceChange(t, typeName, prior, configValue, tftypes.NewValue(schema.ValueType(), proposedAttributes))
+	if !ok {
+		return
+	}
+	if len(requiresReplace) > 0 {
+		t.Errorf("got replacement for %v, want no changes", requiresReplace)
+	}
+	diffs, err := prior.Diff(planned)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, d := range diffs {
+		t.Errorf("got a change of %s from %v to %v, want no changes", d.Path, d.Value1, d.Value2)
+	}
+}
+
+// stringSetValue returns the elements of a set of strings in the order Terraform keeps them.
+func stringSetValue(t *testing.T, value tftypes.Value) []string {
+	t.Helper()
+
+	var elements []tftypes.Value
+	if err := value.As(&elements); err != nil {
+		t.Error(err)
+		return nil
+	}
+	values := make([]string, 0, len(elements))
+	for _, element := range elements {
+		var s string
+		if err := element.As(&s); err != nil {
+			t.Error(err)
+			return nil
+		}
+		values = append(values, s)
+	}
+	return values
+}
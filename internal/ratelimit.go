@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Slack rate limits each Web API method by tier, in requests per minute and per workspace.
+// See https://api.slack.com/apis/rate-limits.
+const (
+	rateTier1 = "tier1"
+	rateTier2 = "tier2"
+	rateTier3 = "tier3"
+	rateTier4 = "tier4"
+)
+
+var tierRequestsPerMinute = map[string]int{
+	rateTier1: 1,
+	rateTier2: 20,
+	rateTier3: 50,
+	rateTier4: 100,
+}
+
+// methodRateTiers is the rate limit tier of every method called by the APIClient.
+var methodRateTiers = map[string]string{
+	// auth.test has a special tier that allows more calls than tier 4.
+	"auth.test":                            rateTier4,
+	"users.lookupByEmail":                  rateTier3,
+	"usergroups.create":                    rateTier2,
+	"usergroups.list":                      rateTier2,
+	"usergroups.update":                    rateTier2,
+	"usergroups.users.list":                rateTier2,
+	"usergroups.users.update":              rateTier2,
+	"usergroups.enable":                    rateTier2,
+	"usergroups.disable":                   rateTier2,
+	"conversations.info":                   rateTier3,
+	"conversations.members":                rateTier4,
+	"conversations.list":                   rateTier2,
+	"conversations.create":                 rateTier2,
+	"conversations.setTopic":               rateTier2,
+	"conversations.setPurpose":             rateTier2,
+	"conversations.rename":                 rateTier2,
+	"conversations.invite":                 rateTier2,
+	"conversations.kick":                   rateTier3,
+	"conversations.archive":                rateTier2,
+	"conversations.unarchive":              rateTier2,
+	"conversations.close":                  rateTier2,
+	"conversations.leave":                  rateTier3,
+	"admin.conversations.convertToPrivate": rateTier2,
+	"admin.conversations.convertToPublic":  rateTier2,
+	"admin.conversations.delete":           rateTier2,
+}
+
+// rateLimitKeys returns the keys accepted by the rate_limits provider attribute.
+func rateLimitKeys() []string {
+	keys := make([]string, 0, len(tierRequestsPerMinute)+len(methodRateTiers))
+	for tier := range tierRequestsPerMinute {
+		keys = append(keys, tier)
+	}
+	for method := range methodRateTiers {
+		keys = append(keys, method)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// methodLimiter spaces out the calls of each method so that they stay under its rate limit.
+// It's shared by every resource and data source of the provider.
+type methodLimiter struct {
+	limiters map[string]*rate.Limiter
+}
+
+// newMethodLimiter returns the limiter for the Slack tiers.
+// overrides replaces the requests per minute of a method or of all the methods of a tier.
+func newMethodLimiter(overrides map[string]int) *methodLimiter {
+	limiters := make(map[string]*rate.Limiter, len(methodRateTiers))
+	for method, tier := range methodRateTiers {
+		perMinute := tierRequestsPerMinute[tier]
+		if n, ok := overrides[tier]; ok {
+			perMinute = n
+		}
+		if n, ok := overrides[method]; ok {
+			perMinute = n
+		}
+		limiters[method] = rate.NewLimiter(rate.Every(time.Minute/time.Duration(perMinute)), 1)
+	}
+	return &methodLimiter{limiters: limiters}
+}
+
+// wait blocks until method may be called, or returns an error if ctx is done first.
+func (l *methodLimiter) wait(ctx context.Context, method string) error {
+	if l == nil {
+		return nil
+	}
+	limiter, ok := l.limiters[method]
+	if !ok {
+		return nil
+	}
+	return limiter.Wait(ctx)
+}
@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestNewMethodLimiter(t *testing.T) {
+	t.Parallel()
+
+	limiter := newMethodLimiter(map[string]int{
+		"tier2":                120,
+		"conversations.invite": 600,
+	})
+
+	tests := map[string]int{
+		"users.lookupByEmail":   50,
+		"conversations.members": 100,
+		"usergroups.update":     120,
+		"conversations.invite":  600,
+	}
+	for method, perMinute := range tests {
+		want := rate.Every(time.Minute / time.Duration(perMinute))
+		if got := limiter.limiters[method].Limit(); got != want {
+			t.Errorf("got limit %v for %s, want %v", got, method, want)
+		}
+	}
+}
+
+func TestMethodLimiterWait(t *testing.T) {
+	t.Parallel()
+
+	limiter := newMethodLimiter(map[string]int{"conversations.invite": 600})
+
+	start := time.Now()
+	for range 3 {
+		if err := limiter.wait(context.Background(), "conversations.invite"); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("3 calls at 600 per minute took %s, want at least 200ms", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := limiter.wait(ctx, "usergroups.create"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := limiter.wait(ctx, "usergroups.create"); err == nil {
+		t.Error("got no error waiting past the deadline")
+	}
+}
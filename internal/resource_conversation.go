@@ -3,33 +3,119 @@ package internal
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/slack-go/slack"
 )
 
 var (
-	_ resource.Resource                = &ResourceConversation{}
-	_ resource.ResourceWithImportState = &ResourceConversation{}
-	_ resource.ResourceWithConfigure   = &ResourceConversation{}
+	_ resource.Resource                 = &ResourceConversation{}
+	_ resource.ResourceWithImportState  = &ResourceConversation{}
+	_ resource.ResourceWithConfigure    = &ResourceConversation{}
+	_ resource.ResourceWithModifyPlan   = &ResourceConversation{}
+	_ resource.ResourceWithUpgradeState = &ResourceConversation{}
 )
 
+// The values of on_name_conflict.
+const (
+	onNameConflictError             = "error"
+	onNameConflictAdopt             = "adopt"
+	onNameConflictUnarchiveAndAdopt = "unarchive_and_adopt"
+)
+
+// The values of delete_behavior.
+const (
+	deleteBehaviorArchive     = "archive"
+	deleteBehaviorAdminDelete = "admin_delete"
+	deleteBehaviorLeave       = "leave"
+	deleteBehaviorAbandon     = "abandon"
+)
+
+const (
+	// maxConversationNameLength is the longest name Slack accepts for a conversation.
+	maxConversationNameLength = 80
+	// defaultArchiveNameTemplate is the name given to a conversation before it is archived when rename_on_archive is set.
+	defaultArchiveNameTemplate = "{name}-archived-{date}"
+	// defaultMemberRemovalWarningThreshold is the number of members a plan can remove before it warns about it.
+	defaultMemberRemovalWarningThreshold = 10
+	// privateKeyPrivacyReplacement marks in private state the destroy of a conversation replaced because is_private changed.
+	privateKeyPrivacyReplacement = "privacy_replacement"
+)
+
+// invalidNameErrors are the errors Slack returns when a conversation name can't be used.
+var invalidNameErrors = []string{
+	"name_taken",
+	"invalid_name",
+	"invalid_name_required",
+	"invalid_name_punctuation",
+	"invalid_name_maxlength",
+	"invalid_name_specials",
+}
+
 type ResourceConversation struct {
-	client APIClient
+	client          APIClient
+	membersPageSize int
+	tokens          tokenKinds
+	scopes          grantedScopes
+	teamID          string
 }
 
-type ResourceConversationState struct {
+// resourceConversationStateV0 is the state of schema version 0, which kept the members in a list.
+type resourceConversationStateV0 struct {
 	ID        types.String `tfsdk:"id"`
 	Name      types.String `tfsdk:"name"`
 	Topic     types.String `tfsdk:"topic"`
 	Purpose   types.String `tfsdk:"purpose"`
 	IsPrivate types.Bool   `tfsdk:"is_private"`
 	Members   types.List   `tfsdk:"members"`
+	TeamID    types.String `tfsdk:"team_id"`
+
+	ConvertPrivacyInPlace types.Bool   `tfsdk:"convert_privacy_in_place"`
+	OnNameConflict        types.String `tfsdk:"on_name_conflict"`
+	DeleteBehavior        types.String `tfsdk:"delete_behavior"`
+	RenameOnArchive       types.Bool   `tfsdk:"rename_on_archive"`
+	ArchiveNameTemplate   types.String `tfsdk:"archive_name_template"`
+}
+
+type ResourceConversationState struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Topic     types.String `tfsdk:"topic"`
+	Purpose   types.String `tfsdk:"purpose"`
+	IsPrivate types.Bool   `tfsdk:"is_private"`
+	Members   types.Set    `tfsdk:"members"`
+	TeamID    types.String `tfsdk:"team_id"`
+
+	ConvertPrivacyInPlace types.Bool   `tfsdk:"convert_privacy_in_place"`
+	OnNameConflict        types.String `tfsdk:"on_name_conflict"`
+	DeleteBehavior        types.String `tfsdk:"delete_behavior"`
+	RenameOnArchive       types.Bool   `tfsdk:"rename_on_archive"`
+	ArchiveNameTemplate   types.String `tfsdk:"archive_name_template"`
+
+	MembersToAdd                  types.Set   `tfsdk:"members_to_add"`
+	MembersToRemove               types.Set   `tfsdk:"members_to_remove"`
+	MemberRemovalWarningThreshold types.Int64 `tfsdk:"member_removal_warning_threshold"`
 }
 
 func NewResourceConversation() resource.Resource {
@@ -42,12 +128,17 @@ func (r *ResourceConversation) Metadata(_ context.Context, req resource.Metadata
 
 func (r *ResourceConversation) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
 	res.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
-				Required: true,
+				Required:   true,
+				Validators: conversationNameValidators(),
 			},
 			"topic": schema.StringAttribute{
 				Optional: true,
@@ -59,36 +150,154 @@ func (r *ResourceConversation) Schema(_ context.Context, _ resource.SchemaReques
 				Computed: true,
 				Optional: true,
 				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfPrivacyChanged,
+						"Changing is_private recreates the conversation unless convert_privacy_in_place is set.",
+						"Changing `is_private` recreates the conversation unless `convert_privacy_in_place` is set.",
+					),
+				},
 			},
-			"members": schema.ListAttribute{
+			"convert_privacy_in_place": schema.BoolAttribute{
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Convert the conversation with admin.conversations.convertToPrivate/convertToPublic when is_private changes. Requires an admin token.",
+			},
+			"on_name_conflict": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				Default:  stringdefault.StaticString(onNameConflictError),
+				Validators: []validator.String{
+					stringvalidator.OneOf(onNameConflictError, onNameConflictAdopt, onNameConflictUnarchiveAndAdopt),
+				},
+				Description: "What to do when the name is already taken on create: error, adopt the existing conversation, " +
+					"or unarchive_and_adopt to also restore an archived one.",
+			},
+			"delete_behavior": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				Default:  stringdefault.StaticString(deleteBehaviorArchive),
+				Validators: []validator.String{
+					stringvalidator.OneOf(deleteBehaviorArchive, deleteBehaviorAdminDelete, deleteBehaviorLeave, deleteBehaviorAbandon),
+				},
+				Description: "What to do with the conversation on destroy: archive it, admin_delete to delete it permanently " +
+					"with an admin token, leave it, or abandon it in Slack and only remove it from state.",
+			},
+			"rename_on_archive": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(false),
+				Description: "Rename the conversation with archive_name_template before archiving it, so that its name can be reused right away. " +
+					"A conversation replaced because is_private changed is always renamed, so that the new one can take its name.",
+			},
+			"archive_name_template": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				Default:  stringdefault.StaticString(defaultArchiveNameTemplate),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^([`+conversationNameChars+`]|\{name\}|\{id\}|\{date\})+$`),
+						"must only contain lowercase letters, letters without case, numbers, hyphens, underscores and the {name}, {id} and {date} placeholders",
+					),
+					stringvalidator.NoneOf("{name}"),
+				},
+				Description: "The name given to the conversation before it is archived. " +
+					"{name} is replaced with the current name, {id} with the conversation ID and {date} with the date as YYYYMMDD.",
+			},
+			"members": schema.SetAttribute{
 				Optional:    true,
 				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(userIDValidator()),
+				},
+			},
+			"members_to_add": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The users the planned apply invites, read from Slack at plan time. Cleared by the next refresh after the apply.",
+			},
+			"members_to_remove": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The users the planned apply kicks, read from Slack at plan time, including the ones who joined on their own. " +
+					"Cleared by the next refresh after the apply.",
+			},
+			"member_removal_warning_threshold": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(defaultMemberRemovalWarningThreshold),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				Description: "Warn at plan time when the apply would remove more members than this.",
+			},
+			"team_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The workspace the conversation is created in. Defaults to the team_id of the provider.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					requiresReplaceIfTeamIDKnown(),
+				},
 			},
 		},
 	}
 }
 
+// requiresReplaceIfPrivacyChanged replaces the conversation when is_private changes,
+// unless the in-place conversion through the admin API is enabled.
+func requiresReplaceIfPrivacyChanged(ctx context.Context, req planmodifier.BoolRequest, res *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+	var convertInPlace types.Bool
+	res.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("convert_privacy_in_place"), &convertInPlace)...)
+	if res.Diagnostics.HasError() || convertInPlace.ValueBool() {
+		return
+	}
+	res.RequiresReplace = true
+
+	// The destroy follows the delete_behavior in state, not the planned one.
+	var deleteBehavior types.String
+	res.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("delete_behavior"), &deleteBehavior)...)
+	switch deleteBehavior.ValueString() {
+	case deleteBehaviorLeave, deleteBehaviorAbandon:
+		res.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"changing is_private recreates the conversation",
+			fmt.Sprintf("With delete_behavior %s the existing conversation keeps its name, so creating the new one fails with name_taken. "+
+				"Apply delete_behavior archive or admin_delete first, or set convert_privacy_in_place to convert it through the admin API instead.",
+				deleteBehavior.ValueString()),
+		)
+	default:
+		res.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"changing is_private recreates the conversation",
+			"The existing conversation is renamed with archive_name_template and archived, or deleted with delete_behavior admin_delete, "+
+				"and a new one is created with its name, so its message history is lost. "+
+				"Set convert_privacy_in_place to convert it through the admin API instead.",
+		)
+	}
+}
+
 func (r *ResourceConversation) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
 	id := req.ID
+	if name, ok := conversationImportName(req.ID); ok {
+		id = r.lookUpConversationID(ctx, name, &res.Diagnostics)
+		if res.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	channel, err := r.client.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
 		ChannelID: id,
 	})
 	if err != nil {
-		res.Diagnostics.AddError(
-			fmt.Sprintf("the conversation with the id %s does not exist", id),
-			err.Error(),
-		)
+		addSlackError(&res.Diagnostics, fmt.Sprintf("the conversation with the id %s does not exist", id), err)
 		return
 	}
 
-	users, _, err := r.client.GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
-		ChannelID: id,
-	})
+	users, err := getConversationMembers(ctx, r.client, id, r.membersPageSize)
 	if err != nil {
-		res.Diagnostics.AddError(
-			fmt.Sprintf("failed to get users in conversation with the id %s", id),
-			err.Error(),
-		)
+		addSlackError(&res.Diagnostics, fmt.Sprintf("failed to get users in conversation with the id %s", id), err)
 		return
 	}
 
@@ -96,7 +305,7 @@ func (r *ResourceConversation) ImportState(ctx context.Context, req resource.Imp
 	for _, user := range users {
 		members = append(members, types.StringValue(user))
 	}
-	memberList, diags := types.ListValue(types.StringType, members)
+	memberSet, diags := types.SetValue(types.StringType, members)
 	res.Diagnostics.Append(diags...)
 	if res.Diagnostics.HasError() {
 		return
@@ -108,7 +317,141 @@ func (r *ResourceConversation) ImportState(ctx context.Context, req resource.Imp
 		Topic:     types.StringValue(channel.Topic.Value),
 		Purpose:   types.StringValue(channel.Purpose.Value),
 		IsPrivate: types.BoolValue(channel.IsPrivate),
-		Members:   memberList,
+		Members:   memberSet,
+		TeamID:    types.StringValue(conversationTeamID(channel, types.StringNull(), r.teamID)),
+
+		ConvertPrivacyInPlace: types.BoolValue(false),
+		OnNameConflict:        types.StringValue(onNameConflictError),
+		DeleteBehavior:        types.StringValue(deleteBehaviorArchive),
+		RenameOnArchive:       types.BoolValue(false),
+		ArchiveNameTemplate:   types.StringValue(defaultArchiveNameTemplate),
+
+		MembersToAdd:                  types.SetValueMust(types.StringType, nil),
+		MembersToRemove:               types.SetValueMust(types.StringType, nil),
+		MemberRemovalWarningThreshold: types.Int64Value(defaultMemberRemovalWarningThreshold),
+	}
+	diags = res.State.Set(ctx, &state)
+	res.Diagnostics.Append(diags...)
+}
+
+// conversationTeamID returns the workspace of channel, or else the default one of the provider.
+// A channel shared between the workspaces of an Enterprise Grid keeps the one already in state.
+func conversationTeamID(channel *slack.Channel, prior types.String, defaultTeamID string) string {
+	if prior.ValueString() != "" && (len(channel.SharedTeamIDs) == 0 || slices.Contains(channel.SharedTeamIDs, prior.ValueString())) {
+		return prior.ValueString()
+	}
+	if len(channel.SharedTeamIDs) > 0 {
+		return channel.SharedTeamIDs[0]
+	}
+	return defaultTeamID
+}
+
+// conversationImportName returns the name of the conversation to import when the import id is #name or name:<name>.
+func conversationImportName(importID string) (string, bool) {
+	if name, ok := strings.CutPrefix(importID, "#"); ok {
+		return name, true
+	}
+	return strings.CutPrefix(importID, "name:")
+}
+
+// lookUpConversationID returns the id of the only conversation named name, archived or not.
+func (r *ResourceConversation) lookUpConversationID(ctx context.Context, name string, diags *diag.Diagnostics) string {
+	channels, err := findConversationsByName(ctx, r.client, name, r.teamID)
+	if err != nil {
+		addSlackError(diags, fmt.Sprintf("failed to look up the conversation named %s", name), err)
+		return ""
+	}
+
+	switch len(channels) {
+	case 0:
+		diags.AddError(
+			fmt.Sprintf("the conversation named %s does not exist", name),
+			"No conversation visible to the token has this name. Private conversations are only visible to their members, so invite the Slack app to it.",
+		)
+		return ""
+	case 1:
+		return channels[0].ID
+	}
+
+	diags.AddError(
+		fmt.Sprintf("%d conversations are named %s", len(channels), name),
+		fmt.Sprintf("Import the one to manage by its id instead: %s.", conversationIDList(channels)),
+	)
+	return ""
+}
+
+// conversationIDList lists the ids of channels, marking the archived ones.
+func conversationIDList(channels []slack.Channel) string {
+	ids := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		if channel.IsArchived {
+			ids = append(ids, channel.ID+" (archived)")
+			continue
+		}
+		ids = append(ids, channel.ID)
+	}
+	return strings.Join(ids, ", ")
+}
+
+func (r *ResourceConversation) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":                       schema.StringAttribute{Computed: true},
+					"name":                     schema.StringAttribute{Required: true},
+					"topic":                    schema.StringAttribute{Optional: true},
+					"purpose":                  schema.StringAttribute{Optional: true},
+					"is_private":               schema.BoolAttribute{Optional: true, Computed: true},
+					"members":                  schema.ListAttribute{Optional: true, ElementType: types.StringType},
+					"team_id":                  schema.StringAttribute{Optional: true, Computed: true},
+					"convert_privacy_in_place": schema.BoolAttribute{Optional: true, Computed: true},
+					"on_name_conflict":         schema.StringAttribute{Optional: true, Computed: true},
+					"delete_behavior":          schema.StringAttribute{Optional: true, Computed: true},
+					"rename_on_archive":        schema.BoolAttribute{Optional: true, Computed: true},
+					"archive_name_template":    schema.StringAttribute{Optional: true, Computed: true},
+				},
+			},
+			StateUpgrader: upgradeConversationStateV0,
+		},
+	}
+}
+
+// upgradeConversationStateV0 moves the members from a list to a set and fills in the defaults of the attributes added since,
+// so that an upgraded conversation has nothing to change.
+func upgradeConversationStateV0(ctx context.Context, req resource.UpgradeStateRequest, res *resource.UpgradeStateResponse) {
+	var prior resourceConversationStateV0
+	diags := req.State.Get(ctx, &prior)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := listToSet(ctx, prior.Members)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	state := ResourceConversationState{
+		ID:        prior.ID,
+		Name:      prior.Name,
+		Topic:     prior.Topic,
+		Purpose:   prior.Purpose,
+		IsPrivate: boolOrDefault(prior.IsPrivate, false),
+		Members:   members,
+		// A missing team_id is recorded by the next refresh.
+		TeamID: prior.TeamID,
+
+		ConvertPrivacyInPlace: boolOrDefault(prior.ConvertPrivacyInPlace, false),
+		OnNameConflict:        stringOrDefault(prior.OnNameConflict, onNameConflictError),
+		DeleteBehavior:        stringOrDefault(prior.DeleteBehavior, deleteBehaviorArchive),
+		RenameOnArchive:       boolOrDefault(prior.RenameOnArchive, false),
+		ArchiveNameTemplate:   stringOrDefault(prior.ArchiveNameTemplate, defaultArchiveNameTemplate),
+
+		MembersToAdd:                  types.SetValueMust(types.StringType, nil),
+		MembersToRemove:               types.SetValueMust(types.StringType, nil),
+		MemberRemovalWarningThreshold: types.Int64Value(defaultMemberRemovalWarningThreshold),
 	}
 	diags = res.State.Set(ctx, &state)
 	res.Diagnostics.Append(diags...)
@@ -118,7 +461,134 @@ func (r *ResourceConversation) Configure(ctx context.Context, req resource.Confi
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(APIClient)
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.tokens = data.tokens
+	r.scopes = data.scopes
+	r.teamID = data.teamID
+	r.membersPageSize = data.membersPageSize
+}
+
+func (r *ResourceConversation) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	r.tokens.require(&res.Diagnostics, tokenKindBot, "slack_conversation")
+
+	var state *ResourceConversationState
+	if !req.State.Raw.IsNull() {
+		state = &ResourceConversationState{}
+		diags := req.State.Get(ctx, state)
+		res.Diagnostics.Append(diags...)
+	}
+	if req.Plan.Raw.IsNull() {
+		if state != nil && state.DeleteBehavior.ValueString() == deleteBehaviorAdminDelete {
+			r.tokens.require(&res.Diagnostics, tokenKindAdmin, "Deleting a slack_conversation with delete_behavior admin_delete")
+			r.scopes.require(&res.Diagnostics, tokenKindAdmin, "Deleting a slack_conversation with delete_behavior admin_delete", "admin.conversations:write")
+		}
+		return
+	}
+
+	var plan ResourceConversationState
+	diags := req.Plan.Get(ctx, &plan)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	if !plan.IsPrivate.IsUnknown() {
+		if plan.IsPrivate.ValueBool() {
+			r.scopes.require(&res.Diagnostics, tokenKindBot, "A private slack_conversation", "groups:read")
+			r.scopes.require(&res.Diagnostics, tokenKindBot, "A private slack_conversation", "groups:write")
+		} else {
+			r.scopes.require(&res.Diagnostics, tokenKindBot, "A public slack_conversation", "channels:read")
+			r.scopes.require(&res.Diagnostics, tokenKindBot, "A public slack_conversation", "channels:manage", "channels:write")
+		}
+	}
+	if plan.DeleteBehavior.ValueString() == deleteBehaviorAdminDelete {
+		r.tokens.require(&res.Diagnostics, tokenKindAdmin, "A slack_conversation with delete_behavior admin_delete")
+		r.scopes.require(&res.Diagnostics, tokenKindAdmin, "A slack_conversation with delete_behavior admin_delete", "admin.conversations:write")
+	}
+	if state != nil && plan.ConvertPrivacyInPlace.ValueBool() && !plan.IsPrivate.Equal(state.IsPrivate) {
+		r.tokens.require(&res.Diagnostics, tokenKindAdmin, "Converting the privacy of a slack_conversation in place")
+		r.scopes.require(&res.Diagnostics, tokenKindAdmin, "Converting the privacy of a slack_conversation in place", "admin.conversations:write")
+	}
+	// The new conversation takes the name of the replaced one, so the destroy renames it even without rename_on_archive.
+	// Terraform hands the planned private state to both the destroy and the create of a replacement.
+	if state != nil && !plan.ConvertPrivacyInPlace.ValueBool() && !plan.IsPrivate.IsUnknown() && !plan.IsPrivate.Equal(state.IsPrivate) {
+		res.Diagnostics.Append(res.Private.SetKey(ctx, privateKeyPrivacyReplacement, []byte("true"))...)
+	}
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing is applied when the plan matches the state, so there are no member changes to preview.
+	if state != nil && req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+	res.Diagnostics.Append(r.planMemberChanges(ctx, state, plan, &res.Plan)...)
+}
+
+// planMemberChanges sets members_to_add and members_to_remove to the changes the apply makes to the members,
+// and warns when more members are removed than member_removal_warning_threshold.
+// They are left unknown when the current members can't be read.
+func (r *ResourceConversation) planMemberChanges(
+	ctx context.Context, state *ResourceConversationState, plan ResourceConversationState, planned *tfsdk.Plan,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.Members.IsUnknown() {
+		return diags
+	}
+	// Unmanaged members are left alone, so nothing changes.
+	if plan.Members.IsNull() {
+		diags.Append(planned.SetAttribute(ctx, path.Root("members_to_add"), types.SetValueMust(types.StringType, nil))...)
+		diags.Append(planned.SetAttribute(ctx, path.Root("members_to_remove"), types.SetValueMust(types.StringType, nil))...)
+		return diags
+	}
+	var members []string
+	diags.Append(plan.Members.ElementsAs(ctx, &members, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// A new conversation only gets the planned members invited.
+	// Adopting an existing conversation on a name conflict isn't known until the apply, so it isn't previewed.
+	var existing []string
+	var creator string
+	if state != nil {
+		if r.client == nil {
+			return diags
+		}
+		var err error
+		existing, creator, err = r.currentMembers(ctx, state.ID.ValueString())
+		if err != nil {
+			detail, _ := slackErrorDetail(err)
+			diags.AddWarning(
+				"failed to preview the member changes of the conversation",
+				fmt.Sprintf("The members of the conversation %s couldn't be read, so members_to_add and members_to_remove are known after apply.\n\n%s",
+					state.ID.ValueString(), detail),
+			)
+			return diags
+		}
+	}
+	toAdd, toRemove := memberChanges(existing, members, creator)
+
+	toAddSet, d := stringSet(ctx, toAdd)
+	diags.Append(d...)
+	toRemoveSet, d := stringSet(ctx, toRemove)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(planned.SetAttribute(ctx, path.Root("members_to_add"), toAddSet)...)
+	diags.Append(planned.SetAttribute(ctx, path.Root("members_to_remove"), toRemoveSet)...)
+
+	if !plan.MemberRemovalWarningThreshold.IsUnknown() && int64(len(toRemove)) > plan.MemberRemovalWarningThreshold.ValueInt64() {
+		diags.AddAttributeWarning(
+			path.Root("members"),
+			fmt.Sprintf("this will remove %d people from #%s", len(toRemove), plan.Name.ValueString()),
+			"Every current member who isn't listed in members is kicked, including the ones who joined on their own. "+
+				"members_to_remove lists them. Add them to members to keep them, "+
+				"or raise member_removal_warning_threshold if the removals are expected.",
+		)
+	}
+	return diags
 }
 
 func (r *ResourceConversation) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
@@ -129,61 +599,201 @@ func (r *ResourceConversation) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	var adopted bool
+	teamID := teamIDOrDefault(plan.TeamID, r.teamID)
 	channel, err := r.client.CreateConversationContext(ctx, slack.CreateConversationParams{
 		ChannelName: plan.Name.ValueString(),
 		IsPrivate:   plan.IsPrivate.ValueBool(),
+		TeamID:      teamID,
 	})
 	if err != nil {
-		res.Diagnostics.AddError("failed to create conversation", err.Error())
-		return
+		if !isSlackError(err, "name_taken") || plan.OnNameConflict.ValueString() == onNameConflictError {
+			addSlackAttributeError(&res.Diagnostics, errorAttributes{name: path.Root("name")}, "failed to create conversation", err)
+			return
+		}
+		channel, diags = r.adoptConversation(ctx, plan, teamID)
+		res.Diagnostics.Append(diags...)
+		if res.Diagnostics.HasError() {
+			return
+		}
+		adopted = true
 	}
 
-	if !plan.Topic.IsNull() {
+	// From here on the conversation exists, so it is saved in state even when a later step fails.
+	// Its state holds the planned values, and the next refresh finds out which of them didn't apply.
+	var incomplete diag.Diagnostics
+
+	// Comparing against the current values also clears the topic and purpose of an adopted conversation.
+	if plan.Topic.ValueString() != channel.Topic.Value {
 		if _, err := r.client.SetTopicOfConversationContext(ctx, channel.ID, plan.Topic.ValueString()); err != nil {
-			res.Diagnostics.AddError("failed to set topic of conversation", err.Error())
-			return
+			addSlackError(&incomplete, "failed to set topic of conversation", err)
 		}
 	}
 
-	if !plan.Purpose.IsNull() {
+	if plan.Purpose.ValueString() != channel.Purpose.Value {
 		if _, err := r.client.SetPurposeOfConversationContext(ctx, channel.ID, plan.Purpose.ValueString()); err != nil {
-			res.Diagnostics.AddError("failed to set purpose of conversation", err.Error())
-			return
+			addSlackError(&incomplete, "failed to set purpose of conversation", err)
 		}
 	}
 
-	if !plan.Members.IsNull() {
-		var commaSeparatedMembers string
-		for _, member := range plan.Members.Elements() {
-			var str string
-			val, err := member.ToTerraformValue(ctx)
-			if err != nil {
-				res.Diagnostics.AddError("failed to convert member to terraform value", err.Error())
-				return
-			}
-			if err := val.As(&str); err != nil {
-				res.Diagnostics.AddError("failed to convert member to string", err.Error())
-				return
-			}
-			commaSeparatedMembers += str + ","
-		}
-		if _, err := r.client.InviteUsersToConversationContext(ctx, channel.ID, strings.TrimRight(commaSeparatedMembers, ",")); err != nil {
-			res.Diagnostics.AddError("failed to invite users to conversation", err.Error())
-			return
+	if adopted && !plan.Members.IsNull() {
+		incomplete.Append(r.reconcileMembers(ctx, channel.ID, plan.Members)...)
+	} else if !plan.Members.IsNull() {
+		var members []string
+		if diags := plan.Members.ElementsAs(ctx, &members, false); diags.HasError() {
+			incomplete.Append(diags...)
+		} else if _, err := r.client.InviteUsersToConversationContext(ctx, channel.ID, strings.Join(members, ",")); err != nil {
+			addSlackAttributeError(&incomplete, errorAttributes{users: path.Root("members")}, "failed to invite users to conversation", err)
 		}
 	}
+	addIncompleteWarnings(&res.Diagnostics, fmt.Sprintf("the conversation %s", channel.ID), incomplete)
 
 	state := ResourceConversationState{
 		ID:        types.StringValue(channel.ID),
 		Name:      types.StringValue(channel.Name),
-		Topic:     types.StringValue(channel.Topic.Value),
-		Purpose:   types.StringValue(channel.Purpose.Value),
+		Topic:     plan.Topic,
+		Purpose:   plan.Purpose,
 		IsPrivate: types.BoolValue(channel.IsPrivate),
 		Members:   plan.Members,
+		TeamID:    types.StringValue(teamID),
+
+		ConvertPrivacyInPlace: plan.ConvertPrivacyInPlace,
+		OnNameConflict:        plan.OnNameConflict,
+		DeleteBehavior:        plan.DeleteBehavior,
+		RenameOnArchive:       plan.RenameOnArchive,
+		ArchiveNameTemplate:   plan.ArchiveNameTemplate,
+
+		MembersToAdd:                  appliedMemberChanges(plan.MembersToAdd),
+		MembersToRemove:               appliedMemberChanges(plan.MembersToRemove),
+		MemberRemovalWarningThreshold: plan.MemberRemovalWarningThreshold,
 	}
 
 	diags = res.State.Set(ctx, &state)
 	res.Diagnostics.Append(diags...)
+	// The marker of a privacy replacement belongs to the replaced conversation.
+	res.Diagnostics.Append(res.Private.SetKey(ctx, privateKeyPrivacyReplacement, nil)...)
+}
+
+// adoptConversation looks up the conversation that already holds the planned name so that it can be taken over.
+func (r *ResourceConversation) adoptConversation(ctx context.Context, plan ResourceConversationState, teamID string) (*slack.Channel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	channels, err := findConversationsByName(ctx, r.client, plan.Name.ValueString(), teamID)
+	if err != nil {
+		addSlackError(&diags, "failed to look up the existing conversation", err)
+		return nil, diags
+	}
+	if len(channels) == 0 {
+		diags.AddAttributeError(
+			path.Root("name"),
+			fmt.Sprintf("the conversation %s can't be adopted", plan.Name.ValueString()),
+			"The name is taken, but the conversation that holds it isn't visible to the token.",
+		)
+		return nil, diags
+	}
+	channel, ok := conversationToAdopt(channels)
+	if !ok {
+		diags.AddAttributeError(
+			path.Root("name"),
+			fmt.Sprintf("the conversation %s can't be adopted", plan.Name.ValueString()),
+			fmt.Sprintf("%d conversations are named %s: %s. Import the one to manage by its id instead.",
+				len(channels), plan.Name.ValueString(), conversationIDList(channels)),
+		)
+		return nil, diags
+	}
+
+	if channel.IsPrivate != plan.IsPrivate.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("is_private"),
+			fmt.Sprintf("the conversation %s can't be adopted", plan.Name.ValueString()),
+			fmt.Sprintf("The existing conversation %s has is_private = %t.", channel.ID, channel.IsPrivate),
+		)
+		return nil, diags
+	}
+
+	if channel.IsArchived {
+		if plan.OnNameConflict.ValueString() != onNameConflictUnarchiveAndAdopt {
+			diags.AddAttributeError(
+				path.Root("name"),
+				fmt.Sprintf("the conversation %s can't be adopted", plan.Name.ValueString()),
+				fmt.Sprintf("The existing conversation %s is archived. Set on_name_conflict to %q to restore it.", channel.ID, onNameConflictUnarchiveAndAdopt),
+			)
+			return nil, diags
+		}
+		if err := r.client.UnArchiveConversationContext(ctx, channel.ID); err != nil {
+			addSlackError(&diags, fmt.Sprintf("failed to unarchive conversation with the id %s", channel.ID), err)
+			return nil, diags
+		}
+		channel.IsArchived = false
+	}
+
+	tflog.Info(ctx, "adopted the existing conversation", map[string]any{"id": channel.ID, "name": channel.Name})
+	return channel, diags
+}
+
+// conversationToAdopt picks the conversation to adopt among the ones holding the planned name.
+// The name is taken by the live conversation if there is one, otherwise only a single archived one can be told apart.
+func conversationToAdopt(channels []slack.Channel) (*slack.Channel, bool) {
+	var live, archived []int
+	for i, channel := range channels {
+		if channel.IsArchived {
+			archived = append(archived, i)
+		} else {
+			live = append(live, i)
+		}
+	}
+	switch {
+	case len(live) == 1:
+		return &channels[live[0]], true
+	case len(live) == 0 && len(archived) == 1:
+		return &channels[archived[0]], true
+	}
+	return nil, false
+}
+
+// getConversationMembers follows every cursor of conversations.members and returns all the members of the conversation.
+func getConversationMembers(ctx context.Context, client APIClient, channelID string, pageSize int) ([]string, error) {
+	params := &slack.GetUsersInConversationParameters{
+		ChannelID: channelID,
+		Limit:     pageSize,
+	}
+	var members []string
+	for {
+		page, cursor, err := client.GetUsersInConversationContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, page...)
+		if cursor == "" {
+			return members, nil
+		}
+		params.Cursor = cursor
+	}
+}
+
+// findConversationsByName lists every public and private channel of the team, archived ones included, and returns those with the given name.
+func findConversationsByName(ctx context.Context, client APIClient, name, teamID string) ([]slack.Channel, error) {
+	params := &slack.GetConversationsParameters{
+		Types:  []string{"public_channel", "private_channel"},
+		Limit:  1000,
+		TeamID: teamID,
+	}
+	var channels []slack.Channel
+	for {
+		page, cursor, err := client.GetConversationsContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, channel := range page {
+			if channel.Name == name {
+				channels = append(channels, channel)
+			}
+		}
+		if cursor == "" {
+			return channels, nil
+		}
+		params.Cursor = cursor
+	}
 }
 
 func (r *ResourceConversation) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
@@ -193,10 +803,75 @@ func (r *ResourceConversation) Read(ctx context.Context, req resource.ReadReques
 	if res.Diagnostics.HasError() {
 		return
 	}
+
+	channel, err := r.client.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
+		ChannelID: state.ID.ValueString(),
+	})
+	if err != nil {
+		if isSlackError(err, "channel_not_found") {
+			tflog.Warn(ctx, "the conversation no longer exists, removing it from state", map[string]any{"id": state.ID.ValueString()})
+			res.State.RemoveResource(ctx)
+			return
+		}
+		addSlackError(&res.Diagnostics, fmt.Sprintf("failed to read conversation with the id %s", state.ID.ValueString()), err)
+		return
+	}
+
+	// An archived channel can't be managed anymore, so treat it as gone and let the next plan recreate it.
+	if channel.IsArchived {
+		tflog.Warn(ctx, "the conversation has been archived, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	users, err := getConversationMembers(ctx, r.client, state.ID.ValueString(), r.membersPageSize)
+	if err != nil {
+		addSlackError(&res.Diagnostics, fmt.Sprintf("failed to get users in conversation with the id %s", state.ID.ValueString()), err)
+		return
+	}
+
+	state.ID = types.StringValue(channel.ID)
+	state.Name = types.StringValue(channel.Name)
+	state.Topic = refreshOptionalString(state.Topic, channel.Topic.Value)
+	state.Purpose = refreshOptionalString(state.Purpose, channel.Purpose.Value)
+	state.IsPrivate = types.BoolValue(channel.IsPrivate)
+	state.TeamID = types.StringValue(conversationTeamID(channel, state.TeamID, r.teamID))
+
+	// Members are only tracked when they are managed by the configuration.
+	if !state.Members.IsNull() {
+		memberSet, diags := refreshMembers(ctx, state.Members, users, channel.Creator)
+		res.Diagnostics.Append(diags...)
+		if res.Diagnostics.HasError() {
+			return
+		}
+		state.Members = memberSet
+	}
+	// The pending member changes only describe a plan, so nothing is pending once the state is refreshed.
+	state.MembersToAdd = types.SetValueMust(types.StringType, nil)
+	state.MembersToRemove = types.SetValueMust(types.StringType, nil)
+
 	diags = res.State.Set(ctx, &state)
 	res.Diagnostics.Append(diags...)
 }
 
+// refreshMembers builds the members set from the users Slack reports.
+// The channel creator joins automatically, so it is ignored unless it was already listed.
+func refreshMembers(ctx context.Context, prior types.Set, users []string, creator string) (types.Set, diag.Diagnostics) {
+	var priorMembers []string
+	diags := prior.ElementsAs(ctx, &priorMembers, false)
+	if diags.HasError() {
+		return prior, diags
+	}
+	members := make([]string, 0, len(users))
+	for _, user := range users {
+		if user == creator && !slices.Contains(priorMembers, user) {
+			continue
+		}
+		members = append(members, user)
+	}
+	return refreshStringSet(ctx, prior, members)
+}
+
 func (r *ResourceConversation) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
 	var plan ResourceConversationState
 	diags := req.Plan.Get(ctx, &plan)
@@ -205,84 +880,177 @@ func (r *ResourceConversation) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	if _, err := r.client.SetTopicOfConversationContext(ctx, plan.ID.ValueString(), plan.Topic.ValueString()); err != nil {
-		res.Diagnostics.AddError("failed to set topic of conversation", err.Error())
-		return
-	}
-
-	if _, err := r.client.SetPurposeOfConversationContext(ctx, plan.ID.ValueString(), plan.Purpose.ValueString()); err != nil {
-		res.Diagnostics.AddError("failed to set purpose of conversation", err.Error())
-		return
-	}
-
-	existingUsers, _, err := r.client.GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
-		ChannelID: plan.ID.ValueString(),
-	})
-	if err != nil {
-		res.Diagnostics.AddError("failed to get users in conversation", err.Error())
+	var prior ResourceConversationState
+	diags = req.State.Get(ctx, &prior)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
 		return
 	}
-	existingUsersMap := make(map[string]struct{}, len(existingUsers))
-	for _, user := range existingUsers {
-		existingUsersMap[user] = struct{}{}
-	}
 
-	members := make(map[string]struct{}, len(plan.Members.Elements()))
-	for _, member := range plan.Members.Elements() {
-		var str string
-		val, err := member.ToTerraformValue(ctx)
+	name := plan.Name
+	if !plan.Name.Equal(prior.Name) {
+		channel, err := r.client.RenameConversationContext(ctx, plan.ID.ValueString(), plan.Name.ValueString())
 		if err != nil {
-			res.Diagnostics.AddError("failed to convert member to terraform value", err.Error())
-			return
-		}
-		if err := val.As(&str); err != nil {
-			res.Diagnostics.AddError("failed to convert member to string", err.Error())
+			if isSlackError(err, invalidNameErrors...) {
+				detail, _ := slackErrorDetail(err)
+				res.Diagnostics.AddAttributeError(
+					path.Root("name"),
+					fmt.Sprintf("failed to rename conversation to %s", plan.Name.ValueString()),
+					fmt.Sprintf("Slack rejected the name: %s", detail),
+				)
+				return
+			}
+			addSlackError(&res.Diagnostics, "failed to rename conversation", err)
 			return
 		}
-		members[str] = struct{}{}
+		name = types.StringValue(channel.Name)
 	}
 
-	var commaSeparatedMembers string
-	for member := range members {
-		if _, ok := existingUsersMap[member]; !ok {
-			commaSeparatedMembers += member + ","
+	// is_private only changes in place when convert_privacy_in_place is set, otherwise the conversation is replaced.
+	if !plan.IsPrivate.Equal(prior.IsPrivate) {
+		var err error
+		visibility := "public"
+		if plan.IsPrivate.ValueBool() {
+			visibility = "private"
+			err = r.client.AdminConversationsConvertToPrivateContext(ctx, plan.ID.ValueString())
+		} else {
+			err = r.client.AdminConversationsConvertToPublicContext(ctx, plan.ID.ValueString())
+		}
+		if err != nil {
+			addSlackError(&res.Diagnostics, fmt.Sprintf("failed to convert conversation to %s", visibility), err)
+			return
 		}
 	}
 
-	var removedMembers []string
-	for member := range existingUsersMap {
-		if _, ok := members[member]; !ok {
-			removedMembers = append(removedMembers, member)
-		}
+	if _, err := r.client.SetTopicOfConversationContext(ctx, plan.ID.ValueString(), plan.Topic.ValueString()); err != nil {
+		addSlackError(&res.Diagnostics, "failed to set topic of conversation", err)
+		return
 	}
 
-	if commaSeparatedMembers != "" {
-		if _, err := r.client.InviteUsersToConversationContext(ctx, plan.ID.ValueString(), strings.TrimRight(commaSeparatedMembers, ",")); err != nil {
-			res.Diagnostics.AddError("failed to invite users to conversation", err.Error())
-			return
-		}
+	if _, err := r.client.SetPurposeOfConversationContext(ctx, plan.ID.ValueString(), plan.Purpose.ValueString()); err != nil {
+		addSlackError(&res.Diagnostics, "failed to set purpose of conversation", err)
+		return
 	}
 
-	for _, member := range removedMembers {
-		if err := r.client.KickUserFromConversationContext(ctx, plan.ID.ValueString(), member); err != nil {
-			res.Diagnostics.AddError("failed to kick user from conversation", err.Error())
+	// Members are only reconciled when they are managed by the configuration, like in Create and Read.
+	// Otherwise they are left to slack_conversation_member and to the users themselves.
+	if !plan.Members.IsNull() {
+		res.Diagnostics.Append(r.reconcileMembers(ctx, plan.ID.ValueString(), plan.Members)...)
+		if res.Diagnostics.HasError() {
 			return
 		}
 	}
 
 	state := ResourceConversationState{
 		ID:        plan.ID,
-		Name:      plan.Name,
+		Name:      name,
 		Topic:     plan.Topic,
 		Purpose:   plan.Purpose,
 		IsPrivate: plan.IsPrivate,
 		Members:   plan.Members,
+		TeamID:    types.StringValue(teamIDOrDefault(plan.TeamID, r.teamID)),
+
+		ConvertPrivacyInPlace: plan.ConvertPrivacyInPlace,
+		OnNameConflict:        plan.OnNameConflict,
+		DeleteBehavior:        plan.DeleteBehavior,
+		RenameOnArchive:       plan.RenameOnArchive,
+		ArchiveNameTemplate:   plan.ArchiveNameTemplate,
+
+		MembersToAdd:                  appliedMemberChanges(plan.MembersToAdd),
+		MembersToRemove:               appliedMemberChanges(plan.MembersToRemove),
+		MemberRemovalWarningThreshold: plan.MemberRemovalWarningThreshold,
 	}
 
 	diags = res.State.Set(ctx, &state)
 	res.Diagnostics.Append(diags...)
 }
 
+// reconcileMembers invites the planned members that are missing and kicks the members that aren't planned.
+func (r *ResourceConversation) reconcileMembers(ctx context.Context, channelID string, planMembers types.Set) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	existingUsers, creator, err := r.currentMembers(ctx, channelID)
+	if err != nil {
+		addSlackError(&diags, "failed to get users in conversation", err)
+		return diags
+	}
+	var members []string
+	if diags := planMembers.ElementsAs(ctx, &members, false); diags.HasError() {
+		return diags
+	}
+	toAdd, toRemove := memberChanges(existingUsers, members, creator)
+
+	if len(toAdd) > 0 {
+		if _, err := r.client.InviteUsersToConversationContext(ctx, channelID, strings.Join(toAdd, ",")); err != nil {
+			addSlackAttributeError(&diags, errorAttributes{users: path.Root("members")}, "failed to invite users to conversation", err)
+			return diags
+		}
+	}
+
+	for _, member := range toRemove {
+		if err := r.client.KickUserFromConversationContext(ctx, channelID, member); err != nil {
+			addSlackAttributeError(&diags, errorAttributes{users: path.Root("members")}, "failed to kick user from conversation", err)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// currentMembers returns the members of the conversation and its creator.
+func (r *ResourceConversation) currentMembers(ctx context.Context, channelID string) ([]string, string, error) {
+	channel, err := r.client.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	members, err := getConversationMembers(ctx, r.client, channelID, r.membersPageSize)
+	if err != nil {
+		return nil, "", err
+	}
+	return members, channel.Creator, nil
+}
+
+// memberChanges returns the planned members missing from the existing members and the existing members that aren't planned.
+// The creator joins automatically and is hidden by refreshMembers, so it's never removed.
+// It's usually the user of the token, which can't kick itself.
+func memberChanges(existing, planned []string, creator string) (toAdd, toRemove []string) {
+	existingMap := make(map[string]struct{}, len(existing))
+	for _, member := range existing {
+		existingMap[member] = struct{}{}
+	}
+	plannedMap := make(map[string]struct{}, len(planned))
+	for _, member := range planned {
+		plannedMap[member] = struct{}{}
+	}
+
+	toAdd, toRemove = []string{}, []string{}
+	for member := range plannedMap {
+		if _, ok := existingMap[member]; !ok {
+			toAdd = append(toAdd, member)
+		}
+	}
+	for member := range existingMap {
+		if _, ok := plannedMap[member]; !ok && member != creator {
+			toRemove = append(toRemove, member)
+		}
+	}
+	slices.Sort(toAdd)
+	slices.Sort(toRemove)
+	return toAdd, toRemove
+}
+
+// appliedMemberChanges is the state of a planned member change once it is applied.
+// The apply keeps the planned changes so that its result matches the plan, and the next refresh clears them.
+// The plan leaves it unknown when the members couldn't be read at plan time.
+func appliedMemberChanges(planned types.Set) types.Set {
+	if planned.IsUnknown() {
+		return types.SetValueMust(types.StringType, nil)
+	}
+	return planned
+}
+
 func (r *ResourceConversation) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
 	var state ResourceConversationState
 	diags := req.State.Get(ctx, &state)
@@ -291,14 +1059,33 @@ func (r *ResourceConversation) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	switch state.DeleteBehavior.ValueString() {
+	case deleteBehaviorAbandon:
+		tflog.Info(ctx, "abandoning the conversation, it is only removed from state", map[string]any{"id": state.ID.ValueString()})
+		return
+	case deleteBehaviorLeave:
+		if _, err := r.client.LeaveConversationContext(ctx, state.ID.ValueString()); err != nil && !r.conversationGone(ctx, state.ID.ValueString(), err) {
+			addSlackError(&res.Diagnostics, "failed to leave conversation", err)
+		}
+		return
+	case deleteBehaviorAdminDelete:
+		if err := r.client.AdminConversationsDeleteContext(ctx, state.ID.ValueString()); err != nil && !r.conversationGone(ctx, state.ID.ValueString(), err) {
+			addSlackError(&res.Diagnostics, "failed to delete conversation", err)
+		}
+		return
+	}
+
 	channel, err := r.client.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
 		ChannelID: state.ID.ValueString(),
 	})
 	if err != nil {
-		res.Diagnostics.AddError(
-			fmt.Sprintf("the conversation with the id %s does not exist", state.ID.String()),
-			err.Error(),
-		)
+		if !r.conversationGone(ctx, state.ID.ValueString(), err) {
+			addSlackError(&res.Diagnostics, fmt.Sprintf("failed to read conversation with the id %s", state.ID.ValueString()), err)
+		}
+		return
+	}
+	if channel.IsArchived {
+		tflog.Info(ctx, "the conversation is already archived", map[string]any{"id": state.ID.ValueString()})
 		return
 	}
 
@@ -306,14 +1093,67 @@ func (r *ResourceConversation) Delete(ctx context.Context, req resource.DeleteRe
 	if user != "" || mpim {
 		if _, closed, err := r.client.CloseConversationContext(ctx, state.ID.ValueString()); err != nil {
 			if !closed {
-				res.Diagnostics.AddError("failed to close conversation", err.Error())
+				addSlackError(&res.Diagnostics, "failed to close conversation", err)
 				return
 			}
 		}
 	} else {
-		if err := r.client.ArchiveConversationContext(ctx, state.ID.ValueString()); err != nil {
-			res.Diagnostics.AddError("failed to archive conversation", err.Error())
+		replacement, diags := req.Private.GetKey(ctx, privateKeyPrivacyReplacement)
+		res.Diagnostics.Append(diags...)
+		if state.RenameOnArchive.ValueBool() || replacement != nil {
+			name := archivedConversationName(state.ArchiveNameTemplate.ValueString(), channel.Name, channel.ID, time.Now())
+			if _, err := r.client.RenameConversationContext(ctx, state.ID.ValueString(), name); err != nil {
+				if r.conversationGone(ctx, state.ID.ValueString(), err) {
+					return
+				}
+				addSlackError(&res.Diagnostics, fmt.Sprintf("failed to rename conversation to %s before archiving it", name), err)
+				return
+			}
+		}
+		if err := r.client.ArchiveConversationContext(ctx, state.ID.ValueString()); err != nil && !r.conversationGone(ctx, state.ID.ValueString(), err) {
+			addSlackError(&res.Diagnostics, "failed to archive conversation", err)
 			return
 		}
 	}
 }
+
+// conversationGone reports whether err means that the conversation was already deleted or archived,
+// in which case there is nothing left to destroy.
+func (r *ResourceConversation) conversationGone(ctx context.Context, id string, err error) bool {
+	if !isSlackError(err, "channel_not_found", "already_archived", "is_archived") {
+		return false
+	}
+	tflog.Warn(ctx, "the conversation is already gone", map[string]any{"id": id, "error": err.Error()})
+	return true
+}
+
+// archivedConversationName renders the archive name template for a conversation.
+// The result is a valid Slack name: it is lowercased, characters Slack doesn't allow become hyphens,
+// and the current name is shortened so that the result fits in 80 characters.
+func archivedConversationName(template, name, id string, now time.Time) string {
+	render := func(name string) string {
+		return strings.NewReplacer(
+			"{name}", name,
+			"{id}", id,
+			"{date}", now.Format("20060102"),
+		).Replace(template)
+	}
+
+	rendered := []rune(sanitizeConversationName(render(name)))
+	if overflow := len(rendered) - maxConversationNameLength; overflow > 0 {
+		nameRunes := []rune(name)
+		rendered = []rune(sanitizeConversationName(render(string(nameRunes[:max(len(nameRunes)-overflow, 0)]))))
+	}
+	if len(rendered) > maxConversationNameLength {
+		rendered = rendered[:maxConversationNameLength]
+	}
+	return string(rendered)
+}
+
+// invalidConversationNameChar matches a character Slack doesn't allow in conversation names.
+var invalidConversationNameChar = regexp.MustCompile(`[^` + conversationNameChars + `]`)
+
+// sanitizeConversationName lowercases name and replaces the characters Slack doesn't allow in names with hyphens.
+func sanitizeConversationName(name string) string {
+	return invalidConversationNameChar.ReplaceAllString(strings.ToLower(name), "-")
+}
@@ -0,0 +1,203 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &ResourceConversationMember{}
+	_ resource.ResourceWithImportState = &ResourceConversationMember{}
+	_ resource.ResourceWithConfigure   = &ResourceConversationMember{}
+	_ resource.ResourceWithModifyPlan  = &ResourceConversationMember{}
+)
+
+type ResourceConversationMember struct {
+	client          APIClient
+	membersPageSize int
+	tokens          tokenKinds
+	scopes          grantedScopes
+}
+
+type ResourceConversationMemberState struct {
+	ID        types.String `tfsdk:"id"`
+	ChannelID types.String `tfsdk:"channel_id"`
+	UserID    types.String `tfsdk:"user_id"`
+}
+
+func NewResourceConversationMember() resource.Resource {
+	return &ResourceConversationMember{}
+}
+
+func (r *ResourceConversationMember) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = fmt.Sprintf("%s_conversation_member", req.ProviderTypeName)
+}
+
+func (r *ResourceConversationMember) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Description: "Manages a single member of a conversation without touching the other members.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"channel_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					conversationIDValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					userIDValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ResourceConversationMember) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	channelID, userID, ok := strings.Cut(req.ID, "/")
+	if !ok || channelID == "" || userID == "" {
+		res.Diagnostics.AddError(
+			fmt.Sprintf("the import id %s is invalid", req.ID),
+			"The import id must be the channel id and the user id separated by a slash, e.g. C123/U456.",
+		)
+		return
+	}
+
+	state := ResourceConversationMemberState{
+		ID:        types.StringValue(conversationMemberID(channelID, userID)),
+		ChannelID: types.StringValue(channelID),
+		UserID:    types.StringValue(userID),
+	}
+	diags := res.State.Set(ctx, &state)
+	res.Diagnostics.Append(diags...)
+}
+
+func (r *ResourceConversationMember) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.tokens = data.tokens
+	r.scopes = data.scopes
+	r.membersPageSize = data.membersPageSize
+}
+
+func (r *ResourceConversationMember) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	r.tokens.require(&res.Diagnostics, tokenKindBot, "slack_conversation_member")
+	// The privacy of the conversation isn't known, so either kind of conversation will do.
+	r.scopes.require(&res.Diagnostics, tokenKindBot, "slack_conversation_member", "channels:read", "groups:read")
+	r.scopes.require(&res.Diagnostics, tokenKindBot, "slack_conversation_member", "channels:manage", "channels:write", "groups:write")
+}
+
+func (r *ResourceConversationMember) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	var plan ResourceConversationMemberState
+	diags := req.Plan.Get(ctx, &plan)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.InviteUsersToConversationContext(ctx, plan.ChannelID.ValueString(), plan.UserID.ValueString()); err != nil {
+		if !isSlackError(err, "already_in_channel") {
+			addSlackAttributeError(&res.Diagnostics, errorAttributes{conversation: path.Root("channel_id"), users: path.Root("user_id")},
+				"failed to invite user to conversation", err)
+			return
+		}
+	}
+
+	state := ResourceConversationMemberState{
+		ID:        types.StringValue(conversationMemberID(plan.ChannelID.ValueString(), plan.UserID.ValueString())),
+		ChannelID: plan.ChannelID,
+		UserID:    plan.UserID,
+	}
+	diags = res.State.Set(ctx, &state)
+	res.Diagnostics.Append(diags...)
+}
+
+func (r *ResourceConversationMember) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	var state ResourceConversationMemberState
+	diags := req.State.Get(ctx, &state)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	users, err := getConversationMembers(ctx, r.client, state.ChannelID.ValueString(), r.membersPageSize)
+	if err != nil {
+		if isSlackError(err, "channel_not_found") {
+			tflog.Warn(ctx, "the conversation no longer exists, removing the member from state", map[string]any{"id": state.ID.ValueString()})
+			res.State.RemoveResource(ctx)
+			return
+		}
+		addSlackError(&res.Diagnostics, fmt.Sprintf("failed to get users in conversation with the id %s", state.ChannelID.ValueString()), err)
+		return
+	}
+
+	if !slices.Contains(users, state.UserID.ValueString()) {
+		tflog.Warn(ctx, "the user is no longer a member of the conversation, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = res.State.Set(ctx, &state)
+	res.Diagnostics.Append(diags...)
+}
+
+func (r *ResourceConversationMember) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	// Every attribute requires replacement, so there is nothing to update in Slack.
+	var plan ResourceConversationMemberState
+	diags := req.Plan.Get(ctx, &plan)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	diags = res.State.Set(ctx, &plan)
+	res.Diagnostics.Append(diags...)
+}
+
+func (r *ResourceConversationMember) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	var state ResourceConversationMemberState
+	diags := req.State.Get(ctx, &state)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.KickUserFromConversationContext(ctx, state.ChannelID.ValueString(), state.UserID.ValueString()); err != nil {
+		if isSlackError(err, "not_in_channel", "channel_not_found") {
+			return
+		}
+		addSlackAttributeError(&res.Diagnostics, errorAttributes{conversation: path.Root("channel_id"), users: path.Root("user_id")},
+			"failed to kick user from conversation", err)
+		return
+	}
+}
+
+// conversationMemberID returns the id of a slack_conversation_member, which is also its import id.
+func conversationMemberID(channelID, userID string) string {
+	return channelID + "/" + userID
+}
@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/slack-go/slack"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sivchari/terraform-provider-slack/internal/mock"
+)
+
+func TestAccConversationMemberResource(t *testing.T) {
+	skipUnlessAcc(t)
+	t.Parallel()
+
+	members := []string{"U123", "U234", "U345"}
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	client.EXPECT().InviteUsersToConversationContext(gomock.Any(), "C123", "U456").DoAndReturn(
+		func(_ context.Context, _ string, users ...string) (*slack.Channel, error) {
+			members = append(members, users...)
+			return &slack.Channel{}, nil
+		},
+	).Times(1)
+	client.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+			return membersPage(members, params)
+		},
+	).AnyTimes()
+	client.EXPECT().KickUserFromConversationContext(gomock.Any(), "C123", "U456").DoAndReturn(
+		func(_ context.Context, _, user string) error {
+			members = slices.DeleteFunc(members, func(member string) bool { return member == user })
+			return nil
+		},
+	).Times(1)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConversationMemberResource(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("slack_conversation_member.test", "id", "C123/U456"),
+					resource.TestCheckResourceAttr("slack_conversation_member.test", "channel_id", "C123"),
+					resource.TestCheckResourceAttr("slack_conversation_member.test", "user_id", "U456"),
+				),
+			},
+			{
+				ResourceName:      "slack_conversation_member.test",
+				ImportState:       true,
+				ImportStateId:     "C123/U456",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "slack_conversation_member.test",
+				ImportState:   true,
+				ImportStateId: "C123",
+				ExpectError:   regexp.MustCompile(`the import id C123 is invalid`),
+			},
+		},
+	})
+}
+
+func testAccConversationMemberResource() string {
+	return providerConfigWithMembersPageSize(2) + `
+resource "slack_conversation_member" "test" {
+	channel_id = "C123"
+	user_id = "U456"
+}`
+}
+
+func TestAccConversationMemberResourceUserTokenScopes(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	// A user token manages public channels with channels:write instead of channels:manage.
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactoriesWithScopes(client, grantedScopes{
+			tokenKindBot: {"channels:read", "channels:write"},
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config:             testAccConversationMemberResource(),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
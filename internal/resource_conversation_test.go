@@ -1,9 +1,25 @@
 package internal
 
 import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/slack-go/slack"
 	"go.uber.org/mock/gomock"
 
@@ -19,55 +35,1338 @@ func TestAccConversationResource(t *testing.T) {
 				ID:        "test",
 				IsPrivate: true,
 			},
-			Name: "test",
+			Name:    "test",
+			Creator: "UBOT",
 			Topic: slack.Topic{
 				Value: "test",
 			},
 			Purpose: slack.Purpose{
 				Value: "test",
 			},
-			Members: []string{"test"},
 		},
 	}
+	// The token's bot created the conversation, so it is a member that isn't managed.
+	members := []string{"UBOT"}
 
 	ctrl := gomock.NewController(t)
 	client := mock.NewMockAPIClient(ctrl)
 
 	client.EXPECT().CreateConversationContext(gomock.Any(), gomock.Any()).Return(&resp, nil).AnyTimes()
-	client.EXPECT().SetTopicOfConversationContext(gomock.Any(), "test", "test").Return(&resp, nil).AnyTimes()
+	client.EXPECT().SetTopicOfConversationContext(gomock.Any(), "test", "test").DoAndReturn(
+		func(_ context.Context, _, topic string) (*slack.Channel, error) {
+			resp.Topic.Value = topic
+			return &resp, nil
+		},
+	).AnyTimes()
 	client.EXPECT().SetPurposeOfConversationContext(gomock.Any(), "test", "test").Return(&resp, nil).AnyTimes()
-	client.EXPECT().InviteUsersToConversationContext(gomock.Any(), "test", "test,test2").Return(&resp, nil).AnyTimes()
-	client.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).Return([]string{"test", "test2", "test3"}, "", nil).AnyTimes()
-	client.EXPECT().KickUserFromConversationContext(gomock.Any(), "test", "test3").Return(nil).AnyTimes()
+	client.EXPECT().InviteUsersToConversationContext(gomock.Any(), "test", "U1,U2").DoAndReturn(
+		func(_ context.Context, _ string, users ...string) (*slack.Channel, error) {
+			for _, user := range users {
+				members = append(members, strings.Split(user, ",")...)
+			}
+			return &resp, nil
+		},
+	).AnyTimes()
+	client.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+			return membersPage(members, params)
+		},
+	).AnyTimes()
+	client.EXPECT().KickUserFromConversationContext(gomock.Any(), "test", "U3").DoAndReturn(
+		func(_ context.Context, _, user string) error {
+			members = slices.DeleteFunc(members, func(member string) bool { return member == user })
+			return nil
+		},
+	).AnyTimes()
 	client.EXPECT().GetConversationInfoContext(gomock.Any(), gomock.Any()).Return(&resp, nil).AnyTimes()
 	client.EXPECT().ArchiveConversationContext(gomock.Any(), "test").Return(nil).AnyTimes()
+	client.EXPECT().RenameConversationContext(gomock.Any(), "test", "renamed").DoAndReturn(
+		func(_ context.Context, _, name string) (*slack.Channel, error) {
+			resp.Name = name
+			return &resp, nil
+		},
+	).AnyTimes()
 
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccConversationResource(),
+				Config: testAccConversationResource("test"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("slack_conversation.test", "id", "test"),
 					resource.TestCheckResourceAttr("slack_conversation.test", "name", "test"),
 					resource.TestCheckResourceAttr("slack_conversation.test", "topic", "test"),
 					resource.TestCheckResourceAttr("slack_conversation.test", "purpose", "test"),
 					resource.TestCheckResourceAttr("slack_conversation.test", "is_private", "true"),
-					resource.TestCheckResourceAttr("slack_conversation.test", "members.0", "test"),
-					resource.TestCheckResourceAttr("slack_conversation.test", "members.1", "test2"),
+					resource.TestCheckResourceAttr("slack_conversation.test", "members.0", "U1"),
+					resource.TestCheckResourceAttr("slack_conversation.test", "members.1", "U2"),
+				),
+			},
+			// Changes made in Slack show up as drift.
+			{
+				PreConfig: func() {
+					resp.Topic.Value = "changed"
+					members = append(members, "U3")
+				},
+				Config:             testAccConversationResource("test"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccConversationResource("test"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("slack_conversation.test", "topic", "test"),
+					resource.TestCheckResourceAttr("slack_conversation.test", "members.#", "2"),
 				),
 			},
+			{
+				Config: testAccConversationResource("renamed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("slack_conversation.test", "id", "test"),
+					resource.TestCheckResourceAttr("slack_conversation.test", "name", "renamed"),
+				),
+			},
+			// A conversation archived outside of Terraform is recreated.
+			{
+				PreConfig: func() {
+					resp.IsArchived = true
+				},
+				Config:             testAccConversationResource("renamed"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
 		},
 	})
 }
 
-func testAccConversationResource() string {
-	return providerConfig + `
+func TestAccConversationResourceRenameError(t *testing.T) {
+	t.Parallel()
+
+	resp := slack.Channel{
+		GroupConversation: slack.GroupConversation{
+			Conversation: slack.Conversation{
+				ID: "test",
+			},
+			Name: "test",
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	client.EXPECT().CreateConversationContext(gomock.Any(), gomock.Any()).Return(&resp, nil).AnyTimes()
+	client.EXPECT().GetConversationInfoContext(gomock.Any(), gomock.Any()).Return(&resp, nil).AnyTimes()
+	client.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).Return(nil, "", nil).AnyTimes()
+	client.EXPECT().RenameConversationContext(gomock.Any(), "test", "taken").Return(nil, slack.SlackErrorResponse{Err: "name_taken"}).AnyTimes()
+	client.EXPECT().ArchiveConversationContext(gomock.Any(), "test").Return(nil).AnyTimes()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConversationResourceName("test"),
+			},
+			{
+				Config:      testAccConversationResourceName("taken"),
+				ExpectError: regexp.MustCompile(`(?s)failed to rename conversation to taken.*name_taken`),
+			},
+		},
+	})
+}
+
+func TestAccConversationResourcePrivacy(t *testing.T) {
+	skipUnlessAcc(t)
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name           string
+		convertInPlace bool
+		action         plancheck.ResourceActionType
+	}{
+		{name: "replace", convertInPlace: false, action: plancheck.ResourceActionDestroyBeforeCreate},
+		{name: "convert in place", convertInPlace: true, action: plancheck.ResourceActionUpdate},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := slack.Channel{
+				GroupConversation: slack.GroupConversation{
+					Conversation: slack.Conversation{
+						ID: "test",
+					},
+					Name: "test",
+				},
+			}
+
+			var created bool
+
+			ctrl := gomock.NewController(t)
+			client := mock.NewMockAPIClient(ctrl)
+
+			// The replaced conversation holds its name even once archived, until it is renamed.
+			client.EXPECT().CreateConversationContext(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, params slack.CreateConversationParams) (*slack.Channel, error) {
+					if created && resp.Name == params.ChannelName {
+						return nil, slack.SlackErrorResponse{Err: "name_taken"}
+					}
+					created = true
+					resp.Name = params.ChannelName
+					resp.IsPrivate = params.IsPrivate
+					resp.IsArchived = false
+					return &resp, nil
+				},
+			).AnyTimes()
+			client.EXPECT().GetConversationInfoContext(gomock.Any(), gomock.Any()).Return(&resp, nil).AnyTimes()
+			client.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).Return(nil, "", nil).AnyTimes()
+			client.EXPECT().ArchiveConversationContext(gomock.Any(), "test").DoAndReturn(
+				func(_ context.Context, _ string) error {
+					resp.IsArchived = true
+					return nil
+				},
+			).AnyTimes()
+			if !tt.convertInPlace {
+				client.EXPECT().RenameConversationContext(gomock.Any(), "test", gomock.Any()).DoAndReturn(
+					func(_ context.Context, _, name string) (*slack.Channel, error) {
+						resp.Name = name
+						return &resp, nil
+					},
+				).Times(1)
+			}
+			if tt.convertInPlace {
+				client.EXPECT().SetTopicOfConversationContext(gomock.Any(), "test", "").Return(&resp, nil).AnyTimes()
+				client.EXPECT().SetPurposeOfConversationContext(gomock.Any(), "test", "").Return(&resp, nil).AnyTimes()
+				client.EXPECT().AdminConversationsConvertToPrivateContext(gomock.Any(), "test").DoAndReturn(
+					func(_ context.Context, _ string) error {
+						resp.IsPrivate = true
+						return nil
+					},
+				).Times(1)
+			}
+
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+				Steps: []resource.TestStep{
+					{
+						Config: testAccConversationResourcePrivacy(false, tt.convertInPlace),
+						Check:  resource.TestCheckResourceAttr("slack_conversation.test", "is_private", "false"),
+					},
+					{
+						Config: testAccConversationResourcePrivacy(true, tt.convertInPlace),
+						ConfigPlanChecks: resource.ConfigPlanChecks{
+							PreApply: []plancheck.PlanCheck{
+								plancheck.ExpectResourceAction("slack_conversation.test", tt.action),
+							},
+						},
+						Check: resource.TestCheckResourceAttr("slack_conversation.test", "is_private", "true"),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccConversationResourceNameConflict(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name           string
+		onNameConflict string
+		archived       bool
+		// olderArchived lists an older archived conversation with the same name before the existing one.
+		olderArchived bool
+		expectError   *regexp.Regexp
+	}{
+		{name: "error", onNameConflict: "error", expectError: regexp.MustCompile(`failed to create conversation`)},
+		{name: "adopt", onNameConflict: "adopt"},
+		{name: "adopt archived", onNameConflict: "adopt", archived: true, expectError: regexp.MustCompile(`is archived`)},
+		{name: "unarchive and adopt", onNameConflict: "unarchive_and_adopt", archived: true},
+		{name: "adopt the live one over an archived one", onNameConflict: "adopt", olderArchived: true},
+		{name: "unarchive and adopt the live one", onNameConflict: "unarchive_and_adopt", olderArchived: true},
+		{
+			name:           "unarchive and adopt ambiguous",
+			onNameConflict: "unarchive_and_adopt",
+			archived:       true,
+			olderArchived:  true,
+			expectError:    regexp.MustCompile(`2 conversations are named test: older \(archived\), existing \(archived\)`),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			existing := slack.Channel{
+				GroupConversation: slack.GroupConversation{
+					Conversation: slack.Conversation{
+						ID: "existing",
+					},
+					Name:       "test",
+					IsArchived: tt.archived,
+					Topic: slack.Topic{
+						Value: "old",
+					},
+				},
+			}
+			members := []string{"U1", "U3"}
+
+			ctrl := gomock.NewController(t)
+			client := mock.NewMockAPIClient(ctrl)
+
+			client.EXPECT().CreateConversationContext(gomock.Any(), gomock.Any()).Return(nil, slack.SlackErrorResponse{Err: "name_taken"}).AnyTimes()
+			client.EXPECT().GetConversationsContext(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+					if params.Cursor == "" {
+						other := slack.Channel{GroupConversation: slack.GroupConversation{Name: "other"}}
+						return []slack.Channel{other}, "next", nil
+					}
+					if tt.olderArchived {
+						older := slack.Channel{GroupConversation: slack.GroupConversation{
+							Conversation: slack.Conversation{ID: "older"},
+							Name:         "test",
+							IsArchived:   true,
+						}}
+						return []slack.Channel{older, existing}, "", nil
+					}
+					return []slack.Channel{existing}, "", nil
+				},
+			).AnyTimes()
+			client.EXPECT().UnArchiveConversationContext(gomock.Any(), "existing").DoAndReturn(
+				func(_ context.Context, _ string) error {
+					existing.IsArchived = false
+					return nil
+				},
+			).AnyTimes()
+			client.EXPECT().SetTopicOfConversationContext(gomock.Any(), "existing", "test").DoAndReturn(
+				func(_ context.Context, _, topic string) (*slack.Channel, error) {
+					existing.Topic.Value = topic
+					return &existing, nil
+				},
+			).AnyTimes()
+			client.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ *slack.GetUsersInConversationParameters) ([]string, string, error) {
+					return slices.Clone(members), "", nil
+				},
+			).AnyTimes()
+			client.EXPECT().InviteUsersToConversationContext(gomock.Any(), "existing", "U2").DoAndReturn(
+				func(_ context.Context, _ string, _ ...string) (*slack.Channel, error) {
+					members = append(members, "U2")
+					return &existing, nil
+				},
+			).AnyTimes()
+			client.EXPECT().KickUserFromConversationContext(gomock.Any(), "existing", "U3").DoAndReturn(
+				func(_ context.Context, _, user string) error {
+					members = slices.DeleteFunc(members, func(member string) bool { return member == user })
+					return nil
+				},
+			).AnyTimes()
+			client.EXPECT().GetConversationInfoContext(gomock.Any(), gomock.Any()).Return(&existing, nil).AnyTimes()
+			client.EXPECT().ArchiveConversationContext(gomock.Any(), "existing").Return(nil).AnyTimes()
+
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccConversationResourceNameConflict(tt.onNameConflict),
+						ExpectError: tt.expectError,
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("slack_conversation.test", "id", "existing"),
+							resource.TestCheckResourceAttr("slack_conversation.test", "topic", "test"),
+							resource.TestCheckResourceAttr("slack_conversation.test", "members.#", "2"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func testAccConversationResourceNameConflict(onNameConflict string) string {
+	return providerConfig + fmt.Sprintf(`
 resource "slack_conversation" "test" {
 	name = "test"
 	topic = "test"
+	members = ["U1", "U2"]
+	on_name_conflict = %q
+}`, onNameConflict)
+}
+
+func TestAccConversationResourceDeleteBehavior(t *testing.T) {
+	skipUnlessAcc(t)
+	t.Parallel()
+
+	for _, deleteBehavior := range []string{"archive", "admin_delete", "leave", "abandon"} {
+		t.Run(deleteBehavior, func(t *testing.T) {
+			t.Parallel()
+
+			resp := slack.Channel{
+				GroupConversation: slack.GroupConversation{
+					Conversation: slack.Conversation{
+						ID: "test",
+					},
+					Name: "test",
+				},
+			}
+
+			ctrl := gomock.NewController(t)
+			client := mock.NewMockAPIClient(ctrl)
+
+			client.EXPECT().CreateConversationContext(gomock.Any(), gomock.Any()).Return(&resp, nil).AnyTimes()
+			client.EXPECT().GetConversationInfoContext(gomock.Any(), gomock.Any()).Return(&resp, nil).AnyTimes()
+			client.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).Return(nil, "", nil).AnyTimes()
+			switch deleteBehavior {
+			case "archive":
+				client.EXPECT().ArchiveConversationContext(gomock.Any(), "test").Return(nil).Times(1)
+			case "admin_delete":
+				client.EXPECT().AdminConversationsDeleteContext(gomock.Any(), "test").Return(nil).Times(1)
+			case "leave":
+				client.EXPECT().LeaveConversationContext(gomock.Any(), "test").Return(false, nil).Times(1)
+			}
+
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+				Steps: []resource.TestStep{
+					{
+						Config: testAccConversationResourceDeleteBehavior(deleteBehavior),
+						Check:  resource.TestCheckResourceAttr("slack_conversation.test", "delete_behavior", deleteBehavior),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccConversationResourceRenameOnArchive(t *testing.T) {
+	skipUnlessAcc(t)
+	t.Parallel()
+
+	resp := slack.Channel{
+		GroupConversation: slack.GroupConversation{
+			Conversation: slack.Conversation{
+				ID: "test",
+			},
+			Name: "test",
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	client.EXPECT().CreateConversationContext(gomock.Any(), gomock.Any()).Return(&resp, nil).AnyTimes()
+	client.EXPECT().GetConversationInfoContext(gomock.Any(), gomock.Any()).Return(&resp, nil).AnyTimes()
+	client.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).Return(nil, "", nil).AnyTimes()
+	gomock.InOrder(
+		client.EXPECT().RenameConversationContext(gomock.Any(), "test", gomock.Cond(func(name any) bool {
+			return regexp.MustCompile(`^test-old-\d{8}$`).MatchString(name.(string))
+		})).Return(&resp, nil).Times(1),
+		client.EXPECT().ArchiveConversationContext(gomock.Any(), "test").Return(nil).Times(1),
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "slack_conversation" "test" {
+	name = "test"
+	rename_on_archive = true
+	archive_name_template = "{name}-old-{date}"
+}`,
+			},
+		},
+	})
+}
+
+func TestArchivedConversationName(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		template string
+		channel  string
+		want     string
+	}{
+		{name: "default", template: defaultArchiveNameTemplate, channel: "incidents", want: "incidents-archived-20240506"},
+		{name: "id", template: "{name}-{id}", channel: "incidents", want: "incidents-c123"},
+		{name: "truncate name", template: defaultArchiveNameTemplate, channel: strings.Repeat("a", 80), want: strings.Repeat("a", 62) + "-archived-20240506"},
+		{name: "truncate template", template: strings.Repeat("b", 81) + "{name}", channel: "incidents", want: strings.Repeat("b", 80)},
+		{name: "non-Latin name", template: defaultArchiveNameTemplate, channel: "雑談", want: "雑談-archived-20240506"},
+		{name: "uppercase non-Latin name", template: defaultArchiveNameTemplate, channel: "Ομάδα Ω", want: "ομάδα-ω-archived-20240506"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := archivedConversationName(tt.template, tt.channel, "C123", now)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if !conversationNameRegexp.MatchString(got) {
+				t.Errorf("got %q, which the name validator rejects", got)
+			}
+		})
+	}
+}
+
+func testAccConversationResourceDeleteBehavior(deleteBehavior string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "slack_conversation" "test" {
+	name = "test"
+	delete_behavior = %q
+}`, deleteBehavior)
+}
+
+func testAccConversationResourcePrivacy(isPrivate, convertInPlace bool) string {
+	return providerConfig + fmt.Sprintf(`
+resource "slack_conversation" "test" {
+	name = "test"
+	is_private = %t
+	convert_privacy_in_place = %t
+}`, isPrivate, convertInPlace)
+}
+
+func testAccConversationResourceName(name string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "slack_conversation" "test" {
+	name = %q
+}`, name)
+}
+
+func testAccConversationResourceMembers(members string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "slack_conversation" "test" {
+	name = "test"
+	members = %s
+	member_removal_warning_threshold = 1
+}`, members)
+}
+
+func testAccConversationResourceTopic(topic string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "slack_conversation" "test" {
+	name = "test"
+	topic = %q
+}`, topic)
+}
+
+func testAccConversationResource(name string) string {
+	return providerConfigWithMembersPageSize(1) + fmt.Sprintf(`
+resource "slack_conversation" "test" {
+	name = %q
+	topic = "test"
 	purpose = "test"
 	is_private = true
-	members = ["test", "test2"]
+	members = ["U1", "U2"]
+}`, name)
+}
+
+func TestAccConversationResourceMissingAdminToken(t *testing.T) {
+	t.Setenv(tokenEnvVar, "")
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "slack" {
+	bot_token = "test"
+	user_token = "test"
+}
+
+resource "slack_conversation" "test" {
+	name = "test"
+	delete_behavior = "admin_delete"
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`delete_behavior admin_delete needs the admin token`),
+			},
+		},
+	})
+}
+
+func TestAccConversationResourceMissingScope(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactoriesWithScopes(client, grantedScopes{
+			tokenKindBot: {"channels:read", "channels:manage"},
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccConversationResourcePrivacy(true, false),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`private slack_conversation needs the groups:read scope`),
+			},
+		},
+	})
+}
+
+func TestAccConversationResourceUserTokenScopes(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	// A user token manages public channels with channels:write instead of channels:manage.
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactoriesWithScopes(client, grantedScopes{
+			tokenKindBot: {"channels:read", "channels:write"},
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config:             testAccConversationResourcePrivacy(false, false),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccConversationResourceTeamID(t *testing.T) {
+	skipUnlessAcc(t)
+	t.Parallel()
+
+	resp := slack.Channel{
+		GroupConversation: slack.GroupConversation{
+			Conversation: slack.Conversation{
+				ID: "test",
+			},
+			Name: "test",
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	client.EXPECT().CreateConversationContext(gomock.Any(), slack.CreateConversationParams{
+		ChannelName: "test",
+		TeamID:      "T1",
+	}).Return(&resp, nil).Times(1)
+	client.EXPECT().GetConversationInfoContext(gomock.Any(), gomock.Any()).Return(&resp, nil).AnyTimes()
+	client.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).Return(nil, "", nil).AnyTimes()
+	client.EXPECT().ArchiveConversationContext(gomock.Any(), "test").Return(nil).Times(1)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "slack" {
+	token = "test"
+	team_id = "T1"
+}
+
+resource "slack_conversation" "test" {
+	name = "test"
+}`,
+				Check: resource.TestCheckResourceAttr("slack_conversation.test", "team_id", "T1"),
+			},
+		},
+	})
+}
+
+func TestAccConversationResourceIncompleteCreate(t *testing.T) {
+	skipUnlessAcc(t)
+	t.Parallel()
+
+	resp := slack.Channel{
+		GroupConversation: slack.GroupConversation{
+			Conversation: slack.Conversation{
+				ID:        "test",
+				IsPrivate: true,
+			},
+			Name: "test",
+		},
+	}
+	var members []string
+	inviteFails := true
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	// The conversation is created once, the retry finishes it instead of colliding with its name.
+	client.EXPECT().CreateConversationContext(gomock.Any(), gomock.Any()).Return(&resp, nil).Times(1)
+	client.EXPECT().SetTopicOfConversationContext(gomock.Any(), "test", "test").DoAndReturn(
+		func(_ context.Context, _, topic string) (*slack.Channel, error) {
+			resp.Topic.Value = topic
+			return &resp, nil
+		},
+	).AnyTimes()
+	client.EXPECT().SetPurposeOfConversationContext(gomock.Any(), "test", "test").DoAndReturn(
+		func(_ context.Context, _, purpose string) (*slack.Channel, error) {
+			resp.Purpose.Value = purpose
+			return &resp, nil
+		},
+	).AnyTimes()
+	client.EXPECT().InviteUsersToConversationContext(gomock.Any(), "test", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, users ...string) (*slack.Channel, error) {
+			if inviteFails {
+				inviteFails = false
+				return nil, slack.SlackErrorResponse{Err: "user_not_found"}
+			}
+			for _, user := range users {
+				members = append(members, strings.Split(user, ",")...)
+			}
+			return &resp, nil
+		},
+	).AnyTimes()
+	client.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+			return membersPage(members, params)
+		},
+	).AnyTimes()
+	client.EXPECT().GetConversationInfoContext(gomock.Any(), gomock.Any()).Return(&resp, nil).AnyTimes()
+	client.EXPECT().ArchiveConversationContext(gomock.Any(), "test").Return(nil).Times(1)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConversationResource("test"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("slack_conversation.test", "id", "test"),
+					resource.TestCheckResourceAttr("slack_conversation.test", "topic", "test"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccConversationResource("test"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("slack_conversation.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("slack_conversation.test", "members.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccConversationResourceAlreadyGone(t *testing.T) {
+	skipUnlessAcc(t)
+	t.Parallel()
+
+	tests := map[string]struct {
+		deleteBehavior string
+		err            string
+	}{
+		"archive not found":          {deleteBehavior: "archive", err: "channel_not_found"},
+		"archive already archived":   {deleteBehavior: "archive", err: "already_archived"},
+		"admin_delete not found":     {deleteBehavior: "admin_delete", err: "channel_not_found"},
+		"leave archived":             {deleteBehavior: "leave", err: "is_archived"},
+		"deleted before the destroy": {deleteBehavior: "archive"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := slack.Channel{
+				GroupConversation: slack.GroupConversation{
+					Conversation: slack.Conversation{
+						ID: "test",
+					},
+					Name: "test",
+				},
+			}
+			var destroying bool
+
+			ctrl := gomock.NewController(t)
+			client := mock.NewMockAPIClient(ctrl)
+
+			client.EXPECT().CreateConversationContext(gomock.Any(), gomock.Any()).Return(&resp, nil).Times(1)
+			client.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).Return(nil, "", nil).AnyTimes()
+			goneErr := slack.SlackErrorResponse{Err: tt.err}
+			switch tt.deleteBehavior {
+			case "archive":
+				if tt.err == "" {
+					// The refresh finds the conversation deleted and removes it from state, so nothing is destroyed.
+					client.EXPECT().GetConversationInfoContext(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, _ *slack.GetConversationInfoInput) (*slack.Channel, error) {
+							if destroying {
+								return nil, slack.SlackErrorResponse{Err: "channel_not_found"}
+							}
+							return &resp, nil
+						},
+					).AnyTimes()
+					break
+				}
+				client.EXPECT().ArchiveConversationContext(gomock.Any(), "test").Return(goneErr).Times(1)
+			case "admin_delete":
+				client.EXPECT().AdminConversationsDeleteContext(gomock.Any(), "test").Return(goneErr).Times(1)
+			case "leave":
+				client.EXPECT().LeaveConversationContext(gomock.Any(), "test").Return(false, goneErr).Times(1)
+			}
+			client.EXPECT().GetConversationInfoContext(gomock.Any(), gomock.Any()).Return(&resp, nil).AnyTimes()
+
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+				Steps: []resource.TestStep{
+					{
+						Config: testAccConversationResourceDeleteBehavior(tt.deleteBehavior),
+					},
+					{
+						PreConfig: func() {
+							destroying = true
+						},
+						Config:  testAccConversationResourceDeleteBehavior(tt.deleteBehavior),
+						Destroy: true,
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccConversationResourceImportNotFound(t *testing.T) {
+	skipUnlessAcc(t)
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	client.EXPECT().GetConversationInfoContext(gomock.Any(), gomock.Any()).Return(nil, slack.SlackErrorResponse{Err: "channel_not_found"}).Times(1)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccConversationResourceName("test"),
+				ResourceName:  "slack_conversation.test",
+				ImportState:   true,
+				ImportStateId: "C404",
+				ExpectError:   regexp.MustCompile(`(?s)the conversation with the id C404 does not exist.*isn't visible to the token`),
+			},
+		},
+	})
+}
+
+func TestAccConversationResourceImportByName(t *testing.T) {
+	t.Parallel()
+
+	channel := func(id, name string, archived bool) slack.Channel {
+		return slack.Channel{
+			GroupConversation: slack.GroupConversation{
+				Conversation: slack.Conversation{ID: id, IsPrivate: true},
+				Name:         name,
+				IsArchived:   archived,
+			},
+		}
+	}
+	// The conversations are listed one per page.
+	pages := [][]slack.Channel{
+		{channel("C1", "general", false)},
+		{channel("C2", "test", false)},
+		{channel("C3", "dup", true)},
+		{channel("C4", "dup", false)},
+	}
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	client.EXPECT().GetConversationsContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+			if !slices.Equal(params.Types, []string{"public_channel", "private_channel"}) {
+				t.Errorf("got types %v, want public and private channels", params.Types)
+			}
+			page := 0
+			if params.Cursor != "" {
+				page, _ = strconv.Atoi(params.Cursor)
+			}
+			cursor := ""
+			if page+1 < len(pages) {
+				cursor = strconv.Itoa(page + 1)
+			}
+			return pages[page], cursor, nil
+		},
+	).AnyTimes()
+	client.EXPECT().GetConversationInfoContext(gomock.Any(), &slack.GetConversationInfoInput{ChannelID: "C2"}).DoAndReturn(
+		func(_ context.Context, _ *slack.GetConversationInfoInput) (*slack.Channel, error) {
+			c := channel("C2", "test", false)
+			return &c, nil
+		},
+	).AnyTimes()
+	client.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).Return(nil, "", nil).AnyTimes()
+
+	for _, importID := range []string{"#test", "name:test"} {
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+			Steps: []resource.TestStep{
+				{
+					Config:        testAccConversationResourceName("test"),
+					ResourceName:  "slack_conversation.test",
+					ImportState:   true,
+					ImportStateId: importID,
+					ImportStateCheck: func(states []*terraform.InstanceState) error {
+						if len(states) != 1 || states[0].ID != "C2" {
+							return fmt.Errorf("got %v, want the conversation C2", states)
+						}
+						return nil
+					},
+				},
+			},
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccConversationResourceName("dup"),
+				ResourceName:  "slack_conversation.test",
+				ImportState:   true,
+				ImportStateId: "#dup",
+				ExpectError:   regexp.MustCompile(`(?s)2 conversations are named dup.*C3 \(archived\), C4`),
+			},
+			{
+				Config:        testAccConversationResourceName("missing"),
+				ResourceName:  "slack_conversation.test",
+				ImportState:   true,
+				ImportStateId: "name:missing",
+				ExpectError:   regexp.MustCompile(`the conversation named missing does not exist`),
+			},
+		},
+	})
+}
+
+func TestAccConversationResourceValidation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	tests := map[string]struct {
+		attributes string
+		wantErr    string
+	}{
+		"uppercase name": {
+			attributes: `name = "General"`,
+			wantErr:    `Attribute name must only contain lowercase letters`,
+		},
+		"name with spaces": {
+			attributes: `name = "new channel"`,
+			wantErr:    `Attribute name must only contain lowercase letters`,
+		},
+		"uppercase non-Latin name": {
+			attributes: `name = "Ομάδα"`,
+			wantErr:    `Attribute name must only contain lowercase letters`,
+		},
+		"non-Latin name": {
+			attributes: `name = "雑談"`,
+		},
+		"long name": {
+			attributes: fmt.Sprintf("name = %q", strings.Repeat("a", 81)),
+			wantErr:    `Attribute name string length must be between 1 and 80`,
+		},
+		"malformed member": {
+			attributes: `name = "test"
+	members = ["U1", "C2"]`,
+			wantErr: `Attribute members\[Value\("C2"\)\] must be a user id`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			step := resource.TestStep{
+				Config: providerConfig + fmt.Sprintf(`
+resource "slack_conversation" "test" {
+	%s
+}`, tt.attributes),
+				PlanOnly: true,
+			}
+			// A valid conversation plans to be created.
+			if tt.wantErr == "" {
+				step.ExpectNonEmptyPlan = true
+			} else {
+				step.ExpectError = regexp.MustCompile(tt.wantErr)
+			}
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
+
+func TestAccConversationResourceMemberChanges(t *testing.T) {
+	skipUnlessAcc(t)
+	t.Parallel()
+
+	resp := slack.Channel{
+		GroupConversation: slack.GroupConversation{
+			Conversation: slack.Conversation{
+				ID: "test",
+			},
+			Name:    "test",
+			Creator: "UBOT",
+		},
+	}
+	// The token's bot created the conversation, so it is a member that is never kicked.
+	members := []string{"UBOT"}
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	client.EXPECT().CreateConversationContext(gomock.Any(), gomock.Any()).Return(&resp, nil).Times(1)
+	client.EXPECT().InviteUsersToConversationContext(gomock.Any(), "test", "U1,U2").DoAndReturn(
+		func(_ context.Context, _ string, users ...string) (*slack.Channel, error) {
+			for _, user := range users {
+				members = append(members, strings.Split(user, ",")...)
+			}
+			return &resp, nil
+		},
+	).Times(1)
+	client.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+			return membersPage(members, params)
+		},
+	).AnyTimes()
+	client.EXPECT().KickUserFromConversationContext(gomock.Any(), "test", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, user string) error {
+			members = slices.DeleteFunc(members, func(member string) bool { return member == user })
+			return nil
+		},
+	).Times(3)
+	client.EXPECT().SetTopicOfConversationContext(gomock.Any(), "test", "").Return(&resp, nil).AnyTimes()
+	client.EXPECT().SetPurposeOfConversationContext(gomock.Any(), "test", "").Return(&resp, nil).AnyTimes()
+	client.EXPECT().GetConversationInfoContext(gomock.Any(), gomock.Any()).Return(&resp, nil).AnyTimes()
+	client.EXPECT().ArchiveConversationContext(gomock.Any(), "test").Return(nil).Times(1)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConversationResourceMembers(`["U1", "U2"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("slack_conversation.test", tfjsonpath.New("members_to_add"),
+							knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("U1"), knownvalue.StringExact("U2")})),
+						plancheck.ExpectKnownValue("slack_conversation.test", tfjsonpath.New("members_to_remove"), knownvalue.SetSizeExact(0)),
+					},
+				},
+			},
+			// The users who joined on their own are kicked along with the ones removed from the configuration.
+			{
+				PreConfig: func() {
+					members = append(members, "U3", "U4")
+				},
+				Config: testAccConversationResourceMembers(`["U1"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("slack_conversation.test", tfjsonpath.New("members_to_add"), knownvalue.SetSizeExact(0)),
+						plancheck.ExpectKnownValue("slack_conversation.test", tfjsonpath.New("members_to_remove"),
+							knownvalue.SetExact([]knownvalue.Check{
+								knownvalue.StringExact("U2"), knownvalue.StringExact("U3"), knownvalue.StringExact("U4"),
+							})),
+					},
+				},
+				Check: func(_ *terraform.State) error {
+					if !slices.Equal(members, []string{"UBOT", "U1"}) {
+						return fmt.Errorf("got members %v, want UBOT and U1", members)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccConversationResourceUnmanagedMembers(t *testing.T) {
+	skipUnlessAcc(t)
+	t.Parallel()
+
+	resp := slack.Channel{
+		GroupConversation: slack.GroupConversation{
+			Conversation: slack.Conversation{
+				ID: "test",
+			},
+			Name: "test",
+		},
+	}
+	members := []string{"UBOT"}
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	// Nobody is kicked, so KickUserFromConversationContext isn't expected.
+	client.EXPECT().CreateConversationContext(gomock.Any(), gomock.Any()).Return(&resp, nil).Times(1)
+	client.EXPECT().SetTopicOfConversationContext(gomock.Any(), "test", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, topic string) (*slack.Channel, error) {
+			resp.Topic.Value = topic
+			return &resp, nil
+		},
+	).AnyTimes()
+	client.EXPECT().SetPurposeOfConversationContext(gomock.Any(), "test", "").Return(&resp, nil).AnyTimes()
+	client.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+			return membersPage(members, params)
+		},
+	).AnyTimes()
+	client.EXPECT().GetConversationInfoContext(gomock.Any(), gomock.Any()).Return(&resp, nil).AnyTimes()
+	client.EXPECT().ArchiveConversationContext(gomock.Any(), "test").Return(nil).Times(1)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConversationResourceTopic("first"),
+			},
+			// Users who joined on their own, or through slack_conversation_member, stay when something else changes.
+			{
+				PreConfig: func() {
+					members = append(members, "U1", "U2")
+				},
+				Config: testAccConversationResourceTopic("second"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("slack_conversation.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("slack_conversation.test", tfjsonpath.New("members_to_remove"), knownvalue.SetSizeExact(0)),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("slack_conversation.test", "topic", "second"),
+					resource.TestCheckNoResourceAttr("slack_conversation.test", "members"),
+				),
+			},
+		},
+	})
+}
+
+func TestConversationResourcePlanMemberChanges(t *testing.T) {
+	t.Parallel()
+
+	// UBOT created the conversations, so it is never removed.
+	tests := map[string]struct {
+		existing     []string
+		members      []string
+		threshold    int64
+		wantToAdd    []string
+		wantToRemove []string
+		wantWarning  bool
+	}{
+		"below the threshold": {
+			existing:     []string{"UBOT", "U1", "U2", "U3"},
+			members:      []string{"U1"},
+			threshold:    2,
+			wantToRemove: []string{"U2", "U3"},
+		},
+		"above the threshold": {
+			existing:     []string{"UBOT", "U1", "U2", "U3"},
+			members:      []string{"U1"},
+			threshold:    1,
+			wantToRemove: []string{"U2", "U3"},
+			wantWarning:  true,
+		},
+		"null members change nothing": {
+			existing:  []string{"UBOT", "U1", "U2"},
+			threshold: 0,
+		},
+		"additions only": {
+			existing:  []string{"UBOT", "U1"},
+			members:   []string{"U1", "U2", "U3"},
+			threshold: 0,
+			wantToAdd: []string{"U2", "U3"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			client := mock.NewMockAPIClient(ctrl)
+			client.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+					return membersPage(tt.existing, params)
+				},
+			).AnyTimes()
+			channel := &slack.Channel{}
+			channel.Creator = "UBOT"
+			client.EXPECT().GetConversationInfoContext(gomock.Any(), gomock.Any()).Return(channel, nil).AnyTimes()
+			r := &ResourceConversation{client: client, membersPageSize: 100}
+
+			var schemaRes fwresource.SchemaResponse
+			r.Schema(ctx, fwresource.SchemaRequest{}, &schemaRes)
+			members := types.SetNull(types.StringType)
+			if tt.members != nil {
+				members, _ = types.SetValueFrom(ctx, types.StringType, tt.members)
+			}
+			state := ResourceConversationState{
+				ID:                            types.StringValue("C1"),
+				Name:                          types.StringValue("incidents"),
+				Members:                       members,
+				MembersToAdd:                  types.SetUnknown(types.StringType),
+				MembersToRemove:               types.SetUnknown(types.StringType),
+				MemberRemovalWarningThreshold: types.Int64Value(tt.threshold),
+			}
+			plan := tfsdk.Plan{Schema: schemaRes.Schema, Raw: tftypes.NewValue(schemaRes.Schema.Type().TerraformType(ctx), nil)}
+			if diags := plan.Set(ctx, &state); diags.HasError() {
+				t.Error(diags)
+				return
+			}
+
+			diags := r.planMemberChanges(ctx, &state, state, &plan)
+			if diags.HasError() {
+				t.Error(diags)
+				return
+			}
+			if got := diags.WarningsCount() > 0; got != tt.wantWarning {
+				t.Errorf("got warning %t, want %t: %v", got, tt.wantWarning, diags)
+			}
+			if tt.wantWarning {
+				want := fmt.Sprintf("this will remove %d people from #incidents", len(tt.wantToRemove))
+				if summary := diags.Warnings()[0].Summary(); summary != want {
+					t.Errorf("got summary %q, want %q", summary, want)
+				}
+			}
+
+			var planned ResourceConversationState
+			if diags := plan.Get(ctx, &planned); diags.HasError() {
+				t.Error(diags)
+				return
+			}
+			var gotToAdd, gotToRemove []string
+			planned.MembersToAdd.ElementsAs(ctx, &gotToAdd, false)
+			planned.MembersToRemove.ElementsAs(ctx, &gotToRemove, false)
+			slices.Sort(gotToAdd)
+			slices.Sort(gotToRemove)
+			if planned.MembersToAdd.IsUnknown() || planned.MembersToRemove.IsUnknown() ||
+				!slices.Equal(gotToAdd, tt.wantToAdd) || !slices.Equal(gotToRemove, tt.wantToRemove) {
+				t.Errorf("got members_to_add %v and members_to_remove %v, want %v and %v", gotToAdd, gotToRemove, tt.wantToAdd, tt.wantToRemove)
+			}
+		})
+	}
+}
+
+func TestConversationTeamID(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		sharedTeamIDs []string
+		prior         types.String
+		want          string
+	}{
+		"recorded from slack":         {sharedTeamIDs: []string{"T2"}, prior: types.StringNull(), want: "T2"},
+		"not reported by slack":       {prior: types.StringNull(), want: "T1"},
+		"kept when still shared":      {sharedTeamIDs: []string{"T2", "T3"}, prior: types.StringValue("T3"), want: "T3"},
+		"moved to another workspace":  {sharedTeamIDs: []string{"T2"}, prior: types.StringValue("T3"), want: "T2"},
+		"empty value of older import": {sharedTeamIDs: []string{"T2"}, prior: types.StringValue(""), want: "T2"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			channel := &slack.Channel{}
+			channel.SharedTeamIDs = tt.sharedTeamIDs
+			if got := conversationTeamID(channel, tt.prior, "T1"); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConversationResourcePlanWithoutTeamID(t *testing.T) {
+	t.Parallel()
+
+	// The state of a conversation created before team_id was tracked, planned with -refresh=false.
+	prior := `{
+	"id": "C1",
+	"name": "test",
+	"topic": "old",
+	"is_private": false,
+	"team_id": null,
+	"convert_privacy_in_place": false,
+	"on_name_conflict": "error",
+	"delete_behavior": "archive",
+	"rename_on_archive": false,
+	"archive_name_template": "{name}-archived-{date}",
+	"members_to_add": [],
+	"members_to_remove": [],
+	"member_removal_warning_threshold": 10
 }`
+	priorValue, ok := resourceValue(t, "slack_conversation", prior)
+	if !ok {
+		return
+	}
+	configValue, ok := resourceValue(t, "slack_conversation", `{"name": "test", "topic": "new"}`)
+	if !ok {
+		return
+	}
+	proposedValue, ok := resourceValue(t, "slack_conversation", strings.Replace(prior, `"topic": "old"`, `"topic": "new"`, 1))
+	if !ok {
+		return
+	}
+	_, requiresReplace, ok := planResourceChange(t, "slack_conversation", priorValue, configValue, proposedValue)
+	if !ok {
+		return
+	}
+
+	if len(requiresReplace) > 0 {
+		t.Errorf("got replacement for %v, want an update in place", requiresReplace)
+	}
+}
+
+func TestConversationResourceUpgradeStateV0(t *testing.T) {
+	t.Parallel()
+
+	attributes, ok := upgradeResourceState(t, "slack_conversation", 0, `{
+	"id": "C1",
+	"name": "test",
+	"topic": "test",
+	"purpose": null,
+	"is_private": true,
+	"members": ["U2", "U1", "U2"],
+	"team_id": "T1",
+	"convert_privacy_in_place": false,
+	"on_name_conflict": "error",
+	"delete_behavior": "archive",
+	"rename_on_archive": false,
+	"archive_name_template": "{name}-archived-{date}"
+}`)
+	if !ok {
+		return
+	}
+
+	var id string
+	if err := attributes["id"].As(&id); err != nil || id != "C1" {
+		t.Errorf("got id %q, want C1", id)
+	}
+	if !attributes["purpose"].IsNull() {
+		t.Errorf("got purpose %v, want null", attributes["purpose"])
+	}
+	members := stringSetValue(t, attributes["members"])
+	slices.Sort(members)
+	if !slices.Equal(members, []string{"U1", "U2"}) {
+		t.Errorf("got members %v, want U1 and U2 once each", members)
+	}
+	var threshold big.Float
+	if err := attributes["member_removal_warning_threshold"].As(&threshold); err != nil || threshold.Cmp(big.NewFloat(defaultMemberRemovalWarningThreshold)) != 0 {
+		t.Errorf("got member_removal_warning_threshold %s, want %d", threshold.String(), defaultMemberRemovalWarningThreshold)
+	}
+}
+
+func TestConversationResourceUpgradeStateV0WithoutMembers(t *testing.T) {
+	t.Parallel()
+
+	// A state written before the later attributes were added upgrades too.
+	attributes, ok := upgradeResourceState(t, "slack_conversation", 0, `{
+	"id": "C1",
+	"name": "test",
+	"is_private": false,
+	"members": null
+}`)
+	if !ok {
+		return
+	}
+
+	if !attributes["members"].IsNull() {
+		t.Errorf("got members %v, want null", attributes["members"])
+	}
+	expectEmptyPlan(t, "slack_conversation", attributes, `{"name": "test"}`)
 }
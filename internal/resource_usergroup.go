@@ -1,29 +1,45 @@
 package internal
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/slack-go/slack"
 )
 
 var (
-	_ resource.Resource                = &ResourceUserGroup{}
-	_ resource.ResourceWithImportState = &ResourceUserGroup{}
-	_ resource.ResourceWithConfigure   = &ResourceUserGroup{}
+	_ resource.Resource                 = &ResourceUserGroup{}
+	_ resource.ResourceWithImportState  = &ResourceUserGroup{}
+	_ resource.ResourceWithConfigure    = &ResourceUserGroup{}
+	_ resource.ResourceWithModifyPlan   = &ResourceUserGroup{}
+	_ resource.ResourceWithUpgradeState = &ResourceUserGroup{}
 )
 
 type ResourceUserGroup struct {
 	client APIClient
+	tokens tokenKinds
+	scopes grantedScopes
+	teamID string
 }
 
-type ResourceUserGroupState struct {
+// resourceUserGroupStateV0 is the state of schema version 0, which kept the channels and users in lists.
+type resourceUserGroupStateV0 struct {
 	ID          types.String `tfsdk:"id"`
 	Name        types.String `tfsdk:"name"`
 	Channels    types.List   `tfsdk:"channels"`
@@ -34,6 +50,17 @@ type ResourceUserGroupState struct {
 	Enabled     types.Bool   `tfsdk:"enabled"`
 }
 
+type ResourceUserGroupState struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Channels    types.Set    `tfsdk:"channels"`
+	Users       types.Set    `tfsdk:"users"`
+	Description types.String `tfsdk:"description"`
+	Handle      types.String `tfsdk:"handle"`
+	TeamID      types.String `tfsdk:"team_id"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+}
+
 func NewResourceUserGroup() resource.Resource {
 	return &ResourceUserGroup{}
 }
@@ -44,29 +71,51 @@ func (r *ResourceUserGroup) Metadata(_ context.Context, req resource.MetadataReq
 
 func (r *ResourceUserGroup) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
 	res.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
-			"channels": schema.ListAttribute{
+			"channels": schema.SetAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(conversationIDValidator()),
+				},
 			},
-			"users": schema.ListAttribute{
+			"users": schema.SetAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(userIDValidator()),
+				},
 			},
 			"description": schema.StringAttribute{
 				Optional: true,
 			},
 			"handle": schema.StringAttribute{
 				Optional: true,
+				Validators: []validator.String{
+					userGroupHandleValidator(),
+				},
 			},
 			"team_id": schema.StringAttribute{
-				Optional: true,
+				Optional:    true,
+				Computed:    true,
+				Description: "The workspace of the usergroup. Defaults to the team_id of the provider.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					requiresReplaceIfTeamIDKnown(),
+				},
 			},
 			"enabled": schema.BoolAttribute{
 				Computed: true,
@@ -78,62 +127,103 @@ func (r *ResourceUserGroup) Schema(_ context.Context, _ resource.SchemaRequest,
 }
 
 func (r *ResourceUserGroup) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
-	userGroups, err := r.client.GetUserGroupsContext(ctx,
+	opts := []slack.GetUserGroupsOption{
 		slack.GetUserGroupsOptionIncludeUsers(true),
-		slack.GetUserGroupsOptionIncludeCount(true),
 		slack.GetUserGroupsOptionIncludeDisabled(true),
-	)
+	}
+	if r.teamID != "" {
+		opts = append(opts, slack.GetUserGroupsOptionWithTeamID(r.teamID))
+	}
+	userGroups, err := r.client.GetUserGroupsContext(ctx, opts...)
 	if err != nil {
-		res.Diagnostics.AddError(
-			fmt.Sprintf("the usergroup that has the id %s does not exist", req.ID),
-			err.Error(),
-		)
+		addSlackError(&res.Diagnostics, fmt.Sprintf("failed to import usergroup with the id %s", req.ID), err)
+		return
 	}
 
-	var userGroup slack.UserGroup
-	for _, ug := range userGroups {
-		if userGroup.ID == req.ID {
-			userGroup = ug
-			break
-		}
+	// The usergroup is imported by its id, @handle or handle:<handle>.
+	match := func(ug slack.UserGroup) bool { return ug.ID == req.ID }
+	if handle, ok := userGroupImportHandle(req.ID); ok {
+		match = func(ug slack.UserGroup) bool { return ug.Handle == handle }
 	}
-
-	if userGroup.ID == "" {
+	idx := slices.IndexFunc(userGroups, match)
+	if idx < 0 {
 		res.Diagnostics.AddError(
-			fmt.Sprintf("the usergroup that has the id %s does not exist", req.ID),
-			"",
+			fmt.Sprintf("the usergroup %s does not exist", req.ID),
+			"Import a usergroup by its id, @handle or handle:<handle>.",
 		)
 		return
 	}
 
-	channels := make([]attr.Value, 0, len(userGroup.Prefs.Channels))
-	for _, channel := range userGroup.Prefs.Channels {
-		channels = append(channels, types.StringValue(channel))
+	// The optional attributes start out null, so that the configuration generated for them is minimal.
+	prior := ResourceUserGroupState{
+		Channels:    types.SetNull(types.StringType),
+		Users:       types.SetNull(types.StringType),
+		Description: types.StringNull(),
+		Handle:      types.StringNull(),
 	}
-	channelList, diags := types.ListValue(types.StringType, channels)
+	state, diags := refreshUserGroupState(ctx, prior, userGroups[idx], r.teamID)
 	res.Diagnostics.Append(diags...)
 	if res.Diagnostics.HasError() {
 		return
 	}
+	diags = res.State.Set(ctx, &state)
+	res.Diagnostics.Append(diags...)
+}
 
-	users := make([]attr.Value, 0, len(userGroup.Users))
-	for _, user := range userGroup.Users {
-		users = append(users, types.StringValue(user))
+// userGroupImportHandle returns the handle of the usergroup to import when the import id is @handle or handle:<handle>.
+func userGroupImportHandle(importID string) (string, bool) {
+	if handle, ok := strings.CutPrefix(importID, "@"); ok {
+		return handle, true
 	}
-	userList, diags := types.ListValue(types.StringType, users)
+	return strings.CutPrefix(importID, "handle:")
+}
+
+func (r *ResourceUserGroup) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":          schema.StringAttribute{Computed: true},
+					"name":        schema.StringAttribute{Required: true},
+					"channels":    schema.ListAttribute{Optional: true, ElementType: types.StringType},
+					"users":       schema.ListAttribute{Optional: true, ElementType: types.StringType},
+					"description": schema.StringAttribute{Optional: true},
+					"handle":      schema.StringAttribute{Optional: true},
+					"team_id":     schema.StringAttribute{Optional: true, Computed: true},
+					"enabled":     schema.BoolAttribute{Optional: true, Computed: true},
+				},
+			},
+			StateUpgrader: upgradeUserGroupStateV0,
+		},
+	}
+}
+
+// upgradeUserGroupStateV0 moves the channels and users from lists to sets and fills in the default of enabled.
+func upgradeUserGroupStateV0(ctx context.Context, req resource.UpgradeStateRequest, res *resource.UpgradeStateResponse) {
+	var prior resourceUserGroupStateV0
+	diags := req.State.Get(ctx, &prior)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	channels, diags := listToSet(ctx, prior.Channels)
+	res.Diagnostics.Append(diags...)
+	users, diags := listToSet(ctx, prior.Users)
 	res.Diagnostics.Append(diags...)
 	if res.Diagnostics.HasError() {
 		return
 	}
 
 	state := ResourceUserGroupState{
-		ID:          types.StringValue(userGroup.ID),
-		Name:        types.StringValue(userGroup.Name),
-		Channels:    channelList,
-		Users:       userList,
-		Description: types.StringValue(userGroup.Description),
-		Handle:      types.StringValue(userGroup.Handle),
-		TeamID:      types.StringValue(userGroup.TeamID),
+		ID:          prior.ID,
+		Name:        prior.Name,
+		Channels:    channels,
+		Users:       users,
+		Description: prior.Description,
+		Handle:      prior.Handle,
+		TeamID:      prior.TeamID,
+		Enabled:     boolOrDefault(prior.Enabled, true),
 	}
 	diags = res.State.Set(ctx, &state)
 	res.Diagnostics.Append(diags...)
@@ -143,7 +233,17 @@ func (r *ResourceUserGroup) Configure(ctx context.Context, req resource.Configur
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(APIClient)
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.tokens = data.tokens
+	r.scopes = data.scopes
+	r.teamID = data.teamID
+}
+
+func (r *ResourceUserGroup) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	r.tokens.require(&res.Diagnostics, tokenKindUser, "slack_usergroup")
+	r.scopes.require(&res.Diagnostics, tokenKindUser, "slack_usergroup", "usergroups:read")
+	r.scopes.require(&res.Diagnostics, tokenKindUser, "slack_usergroup", "usergroups:write")
 }
 
 func (r *ResourceUserGroup) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
@@ -169,6 +269,7 @@ func (r *ResourceUserGroup) Create(ctx context.Context, req resource.CreateReque
 		channels = append(channels, str)
 	}
 
+	teamID := teamIDOrDefault(plan.TeamID, r.teamID)
 	userGroup, err := r.client.CreateUserGroupContext(ctx, slack.UserGroup{
 		Name: plan.Name.ValueString(),
 		Prefs: slack.UserGroupPrefs{
@@ -176,59 +277,47 @@ func (r *ResourceUserGroup) Create(ctx context.Context, req resource.CreateReque
 		},
 		Description: plan.Description.ValueString(),
 		Handle:      plan.Handle.ValueString(),
-		TeamID:      plan.TeamID.ValueString(),
+		TeamID:      teamID,
 	})
 	if err != nil {
-		res.Diagnostics.AddError("failed to create user group", err.Error())
+		addSlackAttributeError(&res.Diagnostics, errorAttributes{name: path.Root("name"), handle: path.Root("handle"), conversation: path.Root("channels")},
+			"failed to create user group", err)
 		return
 	}
 
+	// From here on the user group exists, so it is saved in state even when a later step fails.
+	// Its state holds the planned values, and the next refresh finds out which of them didn't apply.
+	var incomplete diag.Diagnostics
+	stateUserSet := plan.Users
+
 	if !plan.Enabled.ValueBool() {
-		if _, err := r.client.DisableUserGroupContext(ctx, userGroup.ID); err != nil {
-			res.Diagnostics.AddError("failed to disable user group", err.Error())
-			return
-		}
 		// If the user group is disabled, we don't need to update the users
-		return
-	}
-
-	users := make([]string, 0, len(plan.Users.Elements()))
-	for _, user := range plan.Users.Elements() {
-		var str string
-		val, err := user.ToTerraformValue(ctx)
-		if err != nil {
-			res.Diagnostics.AddError("failed to convert user to terraform value", err.Error())
-			return
+		if _, err := r.client.DisableUserGroupContext(ctx, userGroup.ID); err != nil {
+			addSlackError(&incomplete, "failed to disable user group", err)
 		}
-		if err := val.As(&str); err != nil {
-			res.Diagnostics.AddError("failed to convert user to string", err.Error())
-			return
+	} else {
+		var users []string
+		if diags := plan.Users.ElementsAs(ctx, &users, false); diags.HasError() {
+			incomplete.Append(diags...)
+		} else if updated, err := r.client.UpdateUserGroupMembersContext(ctx, userGroup.ID, strings.Join(users, ",")); err != nil {
+			addSlackAttributeError(&incomplete, errorAttributes{users: path.Root("users")}, "failed to update user group members", err)
+		} else {
+			userGroup = updated
+			stateUsers := make([]attr.Value, 0, len(userGroup.Users))
+			for _, user := range userGroup.Users {
+				stateUsers = append(stateUsers, types.StringValue(user))
+			}
+			stateUserSet, diags = types.SetValue(types.StringType, stateUsers)
+			incomplete.Append(diags...)
 		}
-		users = append(users, str)
-	}
-
-	stringedUsers := strings.Join(users, ",")
-	userGroup, err = r.client.UpdateUserGroupMembersContext(ctx, userGroup.ID, stringedUsers)
-	if err != nil {
-		res.Diagnostics.AddError("failed to update user group members", err.Error())
-		return
 	}
+	addIncompleteWarnings(&res.Diagnostics, fmt.Sprintf("the user group %s", userGroup.ID), incomplete)
 
 	stateChannels := make([]attr.Value, 0, len(userGroup.Prefs.Channels))
 	for _, channel := range userGroup.Prefs.Channels {
 		stateChannels = append(stateChannels, types.StringValue(channel))
 	}
-	stateChannelList, diags := types.ListValue(types.StringType, stateChannels)
-	res.Diagnostics.Append(diags...)
-	if res.Diagnostics.HasError() {
-		return
-	}
-
-	stateUsers := make([]attr.Value, 0, len(userGroup.Users))
-	for _, user := range userGroup.Users {
-		stateUsers = append(stateUsers, types.StringValue(user))
-	}
-	stateUserList, diags := types.ListValue(types.StringType, stateUsers)
+	stateChannelSet, diags := types.SetValue(types.StringType, stateChannels)
 	res.Diagnostics.Append(diags...)
 	if res.Diagnostics.HasError() {
 		return
@@ -237,11 +326,11 @@ func (r *ResourceUserGroup) Create(ctx context.Context, req resource.CreateReque
 	state := ResourceUserGroupState{
 		ID:          types.StringValue(userGroup.ID),
 		Name:        types.StringValue(userGroup.Name),
-		Channels:    stateChannelList,
-		Users:       stateUserList,
+		Channels:    stateChannelSet,
+		Users:       stateUserSet,
 		Description: types.StringValue(userGroup.Description),
 		Handle:      types.StringValue(userGroup.Handle),
-		TeamID:      types.StringValue(userGroup.TeamID),
+		TeamID:      types.StringValue(cmp.Or(teamID, userGroup.TeamID)),
 		Enabled:     plan.Enabled,
 	}
 
@@ -256,10 +345,63 @@ func (r *ResourceUserGroup) Read(ctx context.Context, req resource.ReadRequest,
 	if res.Diagnostics.HasError() {
 		return
 	}
+
+	opts := []slack.GetUserGroupsOption{
+		slack.GetUserGroupsOptionIncludeUsers(true),
+		slack.GetUserGroupsOptionIncludeDisabled(true),
+	}
+	teamID := teamIDOrDefault(state.TeamID, r.teamID)
+	if teamID != "" {
+		opts = append(opts, slack.GetUserGroupsOptionWithTeamID(teamID))
+	}
+	userGroups, err := r.client.GetUserGroupsContext(ctx, opts...)
+	if err != nil {
+		addSlackError(&res.Diagnostics, fmt.Sprintf("failed to read usergroup with the id %s", state.ID.ValueString()), err)
+		return
+	}
+
+	idx := slices.IndexFunc(userGroups, func(ug slack.UserGroup) bool {
+		return ug.ID == state.ID.ValueString()
+	})
+	if idx < 0 {
+		tflog.Warn(ctx, "the usergroup no longer exists, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+	state, diags = refreshUserGroupState(ctx, state, userGroups[idx], teamID)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
 	diags = res.State.Set(ctx, &state)
 	res.Diagnostics.Append(diags...)
 }
 
+// refreshUserGroupState returns the state of userGroup.
+// The optional attributes of prior stay null when Slack reports them empty.
+func refreshUserGroupState(
+	ctx context.Context, prior ResourceUserGroupState, userGroup slack.UserGroup, teamID string,
+) (ResourceUserGroupState, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	channelSet, d := refreshStringSet(ctx, prior.Channels, userGroup.Prefs.Channels)
+	diags.Append(d...)
+	userSet, d := refreshStringSet(ctx, prior.Users, userGroup.Users)
+	diags.Append(d...)
+
+	return ResourceUserGroupState{
+		ID:          types.StringValue(userGroup.ID),
+		Name:        types.StringValue(userGroup.Name),
+		Channels:    channelSet,
+		Users:       userSet,
+		Description: refreshOptionalString(prior.Description, userGroup.Description),
+		Handle:      refreshOptionalString(prior.Handle, userGroup.Handle),
+		TeamID:      types.StringValue(cmp.Or(userGroup.TeamID, teamID)),
+		// A disabled usergroup has its deletion date set.
+		Enabled: types.BoolValue(userGroup.DateDelete == 0),
+	}, diags
+}
+
 func (r *ResourceUserGroup) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
 	var plan ResourceUserGroupState
 	diags := req.Plan.Get(ctx, &plan)
@@ -270,12 +412,12 @@ func (r *ResourceUserGroup) Update(ctx context.Context, req resource.UpdateReque
 
 	if plan.Enabled.ValueBool() {
 		if _, err := r.client.EnableUserGroupContext(ctx, plan.ID.ValueString()); err != nil {
-			res.Diagnostics.AddError("failed to enable user group", err.Error())
+			addSlackError(&res.Diagnostics, "failed to enable user group", err)
 			return
 		}
 	} else {
 		if _, err := r.client.DisableUserGroupContext(ctx, plan.ID.ValueString()); err != nil {
-			res.Diagnostics.AddError("failed to disable user group", err.Error())
+			addSlackError(&res.Diagnostics, "failed to disable user group", err)
 			return
 		}
 		// If the user group is disabled, we don't need following process anymore
@@ -302,7 +444,8 @@ func (r *ResourceUserGroup) Update(ctx context.Context, req resource.UpdateReque
 		slack.UpdateUserGroupsOptionChannels(channels),
 		slack.UpdateUserGroupsOptionDescription(plan.Description.ValueStringPointer()),
 	); err != nil {
-		res.Diagnostics.AddError("failed to update user group", err.Error())
+		addSlackAttributeError(&res.Diagnostics, errorAttributes{name: path.Root("name"), handle: path.Root("handle"), conversation: path.Root("channels")},
+			"failed to update user group", err)
 		return
 	}
 
@@ -324,7 +467,7 @@ func (r *ResourceUserGroup) Update(ctx context.Context, req resource.UpdateReque
 
 	userGroup, err := r.client.UpdateUserGroupMembersContext(ctx, plan.ID.ValueString(), stringedUsers)
 	if err != nil {
-		res.Diagnostics.AddError("failed to update user group members", err.Error())
+		addSlackAttributeError(&res.Diagnostics, errorAttributes{users: path.Root("users")}, "failed to update user group members", err)
 		return
 	}
 
@@ -332,7 +475,7 @@ func (r *ResourceUserGroup) Update(ctx context.Context, req resource.UpdateReque
 	for _, channel := range userGroup.Prefs.Channels {
 		stateChannels = append(stateChannels, types.StringValue(channel))
 	}
-	stateChannelList, diags := types.ListValue(types.StringType, stateChannels)
+	stateChannelSet, diags := types.SetValue(types.StringType, stateChannels)
 	res.Diagnostics.Append(diags...)
 	if res.Diagnostics.HasError() {
 		return
@@ -342,7 +485,7 @@ func (r *ResourceUserGroup) Update(ctx context.Context, req resource.UpdateReque
 	for _, user := range userGroup.Users {
 		stateUsers = append(stateUsers, types.StringValue(user))
 	}
-	stateUserList, diags := types.ListValue(types.StringType, stateUsers)
+	stateUserSet, diags := types.SetValue(types.StringType, stateUsers)
 	res.Diagnostics.Append(diags...)
 	if res.Diagnostics.HasError() {
 		return
@@ -351,11 +494,11 @@ func (r *ResourceUserGroup) Update(ctx context.Context, req resource.UpdateReque
 	state := ResourceUserGroupState{
 		ID:          types.StringValue(userGroup.ID),
 		Name:        types.StringValue(userGroup.Name),
-		Channels:    stateChannelList,
-		Users:       stateUserList,
+		Channels:    stateChannelSet,
+		Users:       stateUserSet,
 		Description: types.StringValue(userGroup.Description),
 		Handle:      types.StringValue(userGroup.Handle),
-		TeamID:      types.StringValue(userGroup.TeamID),
+		TeamID:      types.StringValue(cmp.Or(plan.TeamID.ValueString(), userGroup.TeamID)),
 		Enabled:     plan.Enabled,
 	}
 
@@ -372,7 +515,11 @@ func (r *ResourceUserGroup) Delete(ctx context.Context, req resource.DeleteReque
 	}
 
 	if _, err := r.client.DisableUserGroupContext(ctx, state.ID.ValueString()); err != nil {
-		res.Diagnostics.AddError("failed to delete user group", err.Error())
+		if isSlackError(err, "no_such_subteam") {
+			tflog.Warn(ctx, "the usergroup no longer exists", map[string]any{"id": state.ID.ValueString()})
+			return
+		}
+		addSlackError(&res.Diagnostics, "failed to delete user group", err)
 		return
 	}
 }
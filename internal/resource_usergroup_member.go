@@ -0,0 +1,271 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &ResourceUserGroupMember{}
+	_ resource.ResourceWithImportState = &ResourceUserGroupMember{}
+	_ resource.ResourceWithConfigure   = &ResourceUserGroupMember{}
+	_ resource.ResourceWithModifyPlan  = &ResourceUserGroupMember{}
+)
+
+// userGroupLocks serializes the read-modify-write of a usergroup's members,
+// since the members of one usergroup are usually applied in parallel.
+var userGroupLocks = &keyedMutex{}
+
+// errEmptyUserGroup is returned when an update would leave a usergroup without users, which Slack rejects.
+var errEmptyUserGroup = errors.New("a usergroup can't be left without users")
+
+type ResourceUserGroupMember struct {
+	client APIClient
+	tokens tokenKinds
+	scopes grantedScopes
+}
+
+type ResourceUserGroupMemberState struct {
+	ID          types.String `tfsdk:"id"`
+	UserGroupID types.String `tfsdk:"usergroup_id"`
+	UserID      types.String `tfsdk:"user_id"`
+}
+
+func NewResourceUserGroupMember() resource.Resource {
+	return &ResourceUserGroupMember{}
+}
+
+func (r *ResourceUserGroupMember) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = fmt.Sprintf("%s_usergroup_member", req.ProviderTypeName)
+}
+
+func (r *ResourceUserGroupMember) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Description: "Manages a single member of a usergroup without touching the other members.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"usergroup_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					userGroupIDValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					userIDValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ResourceUserGroupMember) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	userGroupID, userID, ok := strings.Cut(req.ID, "/")
+	if !ok || userGroupID == "" || userID == "" {
+		res.Diagnostics.AddError(
+			fmt.Sprintf("the import id %s is invalid", req.ID),
+			"The import id must be the usergroup id and the user id separated by a slash, e.g. S123/U456.",
+		)
+		return
+	}
+
+	state := ResourceUserGroupMemberState{
+		ID:          types.StringValue(userGroupMemberID(userGroupID, userID)),
+		UserGroupID: types.StringValue(userGroupID),
+		UserID:      types.StringValue(userID),
+	}
+	diags := res.State.Set(ctx, &state)
+	res.Diagnostics.Append(diags...)
+}
+
+func (r *ResourceUserGroupMember) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.tokens = data.tokens
+	r.scopes = data.scopes
+}
+
+func (r *ResourceUserGroupMember) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	r.tokens.require(&res.Diagnostics, tokenKindUser, "slack_usergroup_member")
+	r.scopes.require(&res.Diagnostics, tokenKindUser, "slack_usergroup_member", "usergroups:read")
+	r.scopes.require(&res.Diagnostics, tokenKindUser, "slack_usergroup_member", "usergroups:write")
+}
+
+func (r *ResourceUserGroupMember) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	var plan ResourceUserGroupMemberState
+	diags := req.Plan.Get(ctx, &plan)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.updateMembers(ctx, plan.UserGroupID.ValueString(), func(users []string) []string {
+		if slices.Contains(users, plan.UserID.ValueString()) {
+			return users
+		}
+		return append(users, plan.UserID.ValueString())
+	})
+	if err != nil {
+		addSlackAttributeError(&res.Diagnostics, errorAttributes{userGroup: path.Root("usergroup_id"), users: path.Root("user_id")},
+			"failed to add user to usergroup", err)
+		return
+	}
+
+	state := ResourceUserGroupMemberState{
+		ID:          types.StringValue(userGroupMemberID(plan.UserGroupID.ValueString(), plan.UserID.ValueString())),
+		UserGroupID: plan.UserGroupID,
+		UserID:      plan.UserID,
+	}
+	diags = res.State.Set(ctx, &state)
+	res.Diagnostics.Append(diags...)
+}
+
+func (r *ResourceUserGroupMember) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	var state ResourceUserGroupMemberState
+	diags := req.State.Get(ctx, &state)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	users, err := r.client.GetUserGroupMembersContext(ctx, state.UserGroupID.ValueString())
+	if err != nil {
+		if isSlackError(err, "no_such_subteam") {
+			tflog.Warn(ctx, "the usergroup no longer exists, removing the member from state", map[string]any{"id": state.ID.ValueString()})
+			res.State.RemoveResource(ctx)
+			return
+		}
+		addSlackError(&res.Diagnostics, fmt.Sprintf("failed to get users in usergroup with the id %s", state.UserGroupID.ValueString()), err)
+		return
+	}
+
+	if !slices.Contains(users, state.UserID.ValueString()) {
+		tflog.Warn(ctx, "the user is no longer a member of the usergroup, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = res.State.Set(ctx, &state)
+	res.Diagnostics.Append(diags...)
+}
+
+func (r *ResourceUserGroupMember) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	// Every attribute requires replacement, so there is nothing to update in Slack.
+	var plan ResourceUserGroupMemberState
+	diags := req.Plan.Get(ctx, &plan)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	diags = res.State.Set(ctx, &plan)
+	res.Diagnostics.Append(diags...)
+}
+
+func (r *ResourceUserGroupMember) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	var state ResourceUserGroupMemberState
+	diags := req.State.Get(ctx, &state)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.updateMembers(ctx, state.UserGroupID.ValueString(), func(users []string) []string {
+		return slices.DeleteFunc(users, func(user string) bool {
+			return user == state.UserID.ValueString()
+		})
+	})
+	if err != nil {
+		if isSlackError(err, "no_such_subteam") {
+			return
+		}
+		if errors.Is(err, errEmptyUserGroup) {
+			res.Diagnostics.AddAttributeWarning(
+				path.Root("user_id"),
+				fmt.Sprintf("%s is still a member of the usergroup %s", state.UserID.ValueString(), state.UserGroupID.ValueString()),
+				"Slack doesn't allow a usergroup without users, so its last member was only removed from state. "+
+					"Add another member first, or disable or delete the usergroup.",
+			)
+			return
+		}
+		addSlackAttributeError(&res.Diagnostics, errorAttributes{userGroup: path.Root("usergroup_id"), users: path.Root("user_id")},
+			"failed to remove user from usergroup", err)
+		return
+	}
+}
+
+// updateMembers reads the current members of the usergroup, applies modify and writes the result back if it changed.
+// The usergroup is locked meanwhile so that concurrent changes from other members aren't lost.
+func (r *ResourceUserGroupMember) updateMembers(ctx context.Context, userGroupID string, modify func(users []string) []string) error {
+	unlock := userGroupLocks.Lock(userGroupID)
+	defer unlock()
+
+	users, err := r.client.GetUserGroupMembersContext(ctx, userGroupID)
+	if err != nil {
+		return err
+	}
+	updated := modify(slices.Clone(users))
+	if slices.Equal(users, updated) {
+		return nil
+	}
+	if len(updated) == 0 {
+		return errEmptyUserGroup
+	}
+	_, err = r.client.UpdateUserGroupMembersContext(ctx, userGroupID, strings.Join(updated, ","))
+	return err
+}
+
+// userGroupMemberID returns the id of a slack_usergroup_member, which is also its import id.
+func userGroupMemberID(userGroupID, userID string) string {
+	return userGroupID + "/" + userID
+}
+
+// keyedMutex is a set of mutexes identified by a key.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// Lock locks the mutex for key and returns the function that unlocks it.
+func (m *keyedMutex) Lock(key string) func() {
+	m.mu.Lock()
+	if m.locks == nil {
+		m.locks = make(map[string]*sync.Mutex)
+	}
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		m.locks[key] = lock
+	}
+	m.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
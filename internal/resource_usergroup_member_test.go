@@ -0,0 +1,141 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/slack-go/slack"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sivchari/terraform-provider-slack/internal/mock"
+)
+
+func TestAccUserGroupMemberResource(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	members := []string{"U000"}
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	client.EXPECT().GetUserGroupMembersContext(gomock.Any(), "S123").DoAndReturn(
+		func(_ context.Context, _ string) ([]string, error) {
+			mu.Lock()
+			users := slices.Clone(members)
+			mu.Unlock()
+			// Give parallel applies the chance to interleave between reading and writing the members.
+			time.Sleep(10 * time.Millisecond)
+			return users, nil
+		},
+	).AnyTimes()
+	client.EXPECT().UpdateUserGroupMembersContext(gomock.Any(), "S123", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, users string) (slack.UserGroup, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			members = strings.Split(users, ",")
+			return slack.UserGroup{ID: "S123", Users: members}, nil
+		},
+	).AnyTimes()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserGroupMemberResource(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("slack_usergroup_member.test.0", "id", "S123/U001"),
+					resource.TestCheckResourceAttr("slack_usergroup_member.test.0", "usergroup_id", "S123"),
+					resource.TestCheckResourceAttr("slack_usergroup_member.test.0", "user_id", "U001"),
+					func(_ *terraform.State) error {
+						mu.Lock()
+						defer mu.Unlock()
+						for _, user := range []string{"U000", "U001", "U002", "U003"} {
+							if !slices.Contains(members, user) {
+								return fmt.Errorf("%s is missing from the usergroup members %v", user, members)
+							}
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "slack_usergroup_member.test[0]",
+				ImportState:       true,
+				ImportStateId:     "S123/U001",
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if !slices.Equal(members, []string{"U000"}) {
+				return fmt.Errorf("got usergroup members %v after destroy, want [U000]", members)
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccUserGroupMemberResourceLastMember(t *testing.T) {
+	skipUnlessAcc(t)
+	t.Parallel()
+
+	var members []string
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	client.EXPECT().GetUserGroupMembersContext(gomock.Any(), "S123").DoAndReturn(
+		func(_ context.Context, _ string) ([]string, error) {
+			return slices.Clone(members), nil
+		},
+	).AnyTimes()
+	// Only the create updates the members, removing the last one would be rejected by Slack.
+	client.EXPECT().UpdateUserGroupMembersContext(gomock.Any(), "S123", "U001").DoAndReturn(
+		func(_ context.Context, _, users string) (slack.UserGroup, error) {
+			members = strings.Split(users, ",")
+			return slack.UserGroup{ID: "S123", Users: members}, nil
+		},
+	).Times(1)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserGroupMemberResourceSingle(),
+				Check:  resource.TestCheckResourceAttr("slack_usergroup_member.test", "id", "S123/U001"),
+			},
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			if !slices.Equal(members, []string{"U001"}) {
+				return fmt.Errorf("got usergroup members %v after destroy, want [U001]", members)
+			}
+			return nil
+		},
+	})
+}
+
+func testAccUserGroupMemberResource() string {
+	return providerConfig + `
+resource "slack_usergroup_member" "test" {
+	count = 3
+	usergroup_id = "S123"
+	user_id = "U00${count.index + 1}"
+}`
+}
+
+func testAccUserGroupMemberResourceSingle() string {
+	return providerConfig + `
+resource "slack_usergroup_member" "test" {
+	usergroup_id = "S123"
+	user_id = "U001"
+}`
+}
@@ -1,9 +1,14 @@
 package internal
 
 import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/slack-go/slack"
 	"go.uber.org/mock/gomock"
 
@@ -17,13 +22,14 @@ func TestAccUserGroupResource(t *testing.T) {
 		ID:   "test",
 		Name: "test",
 		Prefs: slack.UserGroupPrefs{
-			Channels: []string{"test"},
+			Channels: []string{"C1"},
 		},
-		Users:       []string{"test"},
+		Users:       []string{"U1"},
 		Description: "test",
 		Handle:      "test",
 		TeamID:      "test",
 	}
+	var deleted bool
 
 	ctrl := gomock.NewController(t)
 	client := mock.NewMockAPIClient(ctrl)
@@ -32,7 +38,15 @@ func TestAccUserGroupResource(t *testing.T) {
 	client.EXPECT().CreateUserGroupContext(gomock.Any(), gomock.Any()).Return(resp, nil).AnyTimes()
 	client.EXPECT().DisableUserGroupContext(gomock.Any(), "test").Return(resp, nil).AnyTimes()
 	client.EXPECT().UpdateUserGroupContext(gomock.Any(), gomock.Any()).Return(resp, nil).AnyTimes()
-	client.EXPECT().UpdateUserGroupMembersContext(gomock.Any(), "test", "test").Return(resp, nil).AnyTimes()
+	client.EXPECT().UpdateUserGroupMembersContext(gomock.Any(), "test", "U1").Return(resp, nil).AnyTimes()
+	client.EXPECT().GetUserGroupsContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ ...slack.GetUserGroupsOption) ([]slack.UserGroup, error) {
+			if deleted {
+				return nil, nil
+			}
+			return []slack.UserGroup{resp}, nil
+		},
+	).AnyTimes()
 
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
@@ -42,14 +56,33 @@ func TestAccUserGroupResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("slack_usergroup.test", "id", "test"),
 					resource.TestCheckResourceAttr("slack_usergroup.test", "name", "test"),
-					resource.TestCheckResourceAttr("slack_usergroup.test", "channels.0", "test"),
-					resource.TestCheckResourceAttr("slack_usergroup.test", "users.0", "test"),
+					resource.TestCheckResourceAttr("slack_usergroup.test", "channels.0", "C1"),
+					resource.TestCheckResourceAttr("slack_usergroup.test", "users.0", "U1"),
 					resource.TestCheckResourceAttr("slack_usergroup.test", "description", "test"),
 					resource.TestCheckResourceAttr("slack_usergroup.test", "handle", "test"),
 					resource.TestCheckResourceAttr("slack_usergroup.test", "team_id", "test"),
 					resource.TestCheckResourceAttr("slack_usergroup.test", "enabled", "true"),
 				),
 			},
+			// Changes made in Slack show up as drift.
+			{
+				PreConfig: func() {
+					resp.Description = "changed"
+					resp.DateDelete = 1
+				},
+				Config:             testAccUserGroupResource(),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// A usergroup that no longer exists is recreated.
+			{
+				PreConfig: func() {
+					deleted = true
+				},
+				Config:             testAccUserGroupResource(),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
 		},
 	})
 }
@@ -58,10 +91,370 @@ func testAccUserGroupResource() string {
 	return providerConfig + `
 resource "slack_usergroup" "test" {
 	name = "test"
-	channels = ["test"]
-	users = ["test"]
+	channels = ["C1"]
+	users = ["U1"]
 	description = "test"
 	handle = "test"
 	team_id = "test"
 }`
 }
+
+func TestAccUserGroupResourceMissingUserToken(t *testing.T) {
+	t.Setenv(tokenEnvVar, "")
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "slack" {
+	bot_token = "test"
+}
+
+resource "slack_usergroup" "test" {
+	name = "test"
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`slack_usergroup needs the user token`),
+			},
+		},
+	})
+}
+
+func TestAccUserGroupResourceMissingScope(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactoriesWithScopes(client, grantedScopes{
+			tokenKindBot:  {"channels:read", "channels:manage"},
+			tokenKindUser: {"usergroups:read"},
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccUserGroupResource(),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`slack_usergroup needs the usergroups:write scope`),
+			},
+		},
+	})
+}
+
+func TestAccUserGroupResourceIncompleteCreate(t *testing.T) {
+	skipUnlessAcc(t)
+	t.Parallel()
+
+	resp := slack.UserGroup{
+		ID:   "test",
+		Name: "test",
+		Prefs: slack.UserGroupPrefs{
+			Channels: []string{"C1"},
+		},
+		Description: "test",
+		Handle:      "test",
+		TeamID:      "test",
+	}
+	updateFails := true
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	// The user group is created once, the retry finishes it instead of colliding with its name.
+	client.EXPECT().CreateUserGroupContext(gomock.Any(), gomock.Any()).Return(resp, nil).Times(1)
+	client.EXPECT().EnableUserGroupContext(gomock.Any(), "test").Return(resp, nil).AnyTimes()
+	client.EXPECT().UpdateUserGroupContext(gomock.Any(), "test", gomock.Any()).Return(resp, nil).AnyTimes()
+	client.EXPECT().UpdateUserGroupMembersContext(gomock.Any(), "test", "U1").DoAndReturn(
+		func(_ context.Context, _, users string) (slack.UserGroup, error) {
+			if updateFails {
+				updateFails = false
+				return slack.UserGroup{}, slack.SlackErrorResponse{Err: "invalid_users"}
+			}
+			resp.Users = []string{users}
+			return resp, nil
+		},
+	).AnyTimes()
+	client.EXPECT().GetUserGroupsContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ ...slack.GetUserGroupsOption) ([]slack.UserGroup, error) {
+			return []slack.UserGroup{resp}, nil
+		},
+	).AnyTimes()
+	client.EXPECT().DisableUserGroupContext(gomock.Any(), "test").Return(resp, nil).AnyTimes()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config:             testAccUserGroupResource(),
+				Check:              resource.TestCheckResourceAttr("slack_usergroup.test", "id", "test"),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccUserGroupResource(),
+				Check:  resource.TestCheckResourceAttr("slack_usergroup.test", "users.0", "U1"),
+			},
+		},
+	})
+}
+
+func TestAccUserGroupResourceAlreadyGone(t *testing.T) {
+	skipUnlessAcc(t)
+	t.Parallel()
+
+	resp := slack.UserGroup{
+		ID:   "test",
+		Name: "test",
+		Prefs: slack.UserGroupPrefs{
+			Channels: []string{"C1"},
+		},
+		Users:       []string{"U1"},
+		Description: "test",
+		Handle:      "test",
+		TeamID:      "test",
+	}
+	var deleted bool
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	client.EXPECT().CreateUserGroupContext(gomock.Any(), gomock.Any()).Return(resp, nil).Times(1)
+	client.EXPECT().UpdateUserGroupMembersContext(gomock.Any(), "test", "U1").Return(resp, nil).AnyTimes()
+	client.EXPECT().GetUserGroupsContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ ...slack.GetUserGroupsOption) ([]slack.UserGroup, error) {
+			if deleted {
+				return nil, nil
+			}
+			return []slack.UserGroup{resp}, nil
+		},
+	).AnyTimes()
+	client.EXPECT().DisableUserGroupContext(gomock.Any(), "test").Return(slack.UserGroup{}, slack.SlackErrorResponse{Err: "no_such_subteam"}).Times(1)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserGroupResource(),
+			},
+			// A user group deleted outside of Terraform is removed from state by the refresh.
+			{
+				PreConfig: func() {
+					deleted = true
+				},
+				Config:             testAccUserGroupResource(),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// The destroy succeeds when the user group is gone by then.
+			{
+				PreConfig: func() {
+					deleted = false
+				},
+				Config:  testAccUserGroupResource(),
+				Destroy: true,
+			},
+		},
+	})
+}
+
+func TestAccUserGroupResourceImportError(t *testing.T) {
+	skipUnlessAcc(t)
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	client.EXPECT().GetUserGroupsContext(gomock.Any(), gomock.Any()).Return(nil, slack.SlackErrorResponse{Err: "missing_scope"}).Times(1)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccUserGroupResource(),
+				ResourceName:  "slack_usergroup.test",
+				ImportState:   true,
+				ImportStateId: "S404",
+				ExpectError:   regexp.MustCompile(`(?s)failed to import usergroup with the id S404.*usergroups.list needs the usergroups:read scope`),
+			},
+		},
+	})
+}
+
+func TestAccUserGroupResourceImport(t *testing.T) {
+	t.Parallel()
+
+	resp := slack.UserGroup{
+		ID:   "test",
+		Name: "test",
+		Prefs: slack.UserGroupPrefs{
+			Channels: []string{"C1"},
+		},
+		Users:       []string{"U1"},
+		Description: "test",
+		Handle:      "test",
+		TeamID:      "test",
+	}
+	disabled := slack.UserGroup{
+		ID:         "disabled",
+		Name:       "disabled",
+		Handle:     "disabled",
+		TeamID:     "test",
+		DateDelete: 1,
+	}
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	client.EXPECT().CreateUserGroupContext(gomock.Any(), gomock.Any()).Return(resp, nil).AnyTimes()
+	client.EXPECT().UpdateUserGroupMembersContext(gomock.Any(), "test", "U1").Return(resp, nil).AnyTimes()
+	client.EXPECT().GetUserGroupsContext(gomock.Any(), gomock.Any()).Return([]slack.UserGroup{disabled, resp}, nil).AnyTimes()
+	client.EXPECT().DisableUserGroupContext(gomock.Any(), "test").Return(resp, nil).AnyTimes()
+
+	steps := []resource.TestStep{
+		{
+			Config: testAccUserGroupResource(),
+		},
+	}
+	for _, importID := range []string{"test", "@test", "handle:test"} {
+		steps = append(steps, resource.TestStep{
+			ResourceName:      "slack_usergroup.test",
+			ImportState:       true,
+			ImportStateId:     importID,
+			ImportStateVerify: true,
+		})
+	}
+	steps = append(steps,
+		resource.TestStep{
+			ResourceName:  "slack_usergroup.test",
+			ImportState:   true,
+			ImportStateId: "@disabled",
+			ImportStateCheck: func(states []*terraform.InstanceState) error {
+				if len(states) != 1 {
+					return fmt.Errorf("got %d usergroups, want 1", len(states))
+				}
+				attributes := states[0].Attributes
+				if attributes["id"] != "disabled" || attributes["enabled"] != "false" {
+					return fmt.Errorf("got id %s and enabled %s, want the disabled usergroup", attributes["id"], attributes["enabled"])
+				}
+				if _, ok := attributes["description"]; ok {
+					return fmt.Errorf("got description %q, want it unset", attributes["description"])
+				}
+				return nil
+			},
+		},
+		resource.TestStep{
+			ResourceName:  "slack_usergroup.test",
+			ImportState:   true,
+			ImportStateId: "@missing",
+			ExpectError:   regexp.MustCompile(`the usergroup @missing does not exist`),
+		},
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+		Steps:                    steps,
+	})
+}
+
+func TestAccUserGroupResourceValidation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockAPIClient(ctrl)
+
+	tests := map[string]struct {
+		attributes string
+		wantErr    string
+	}{
+		"empty name": {
+			attributes: `name = ""`,
+			wantErr:    `Attribute name string length must be at least 1`,
+		},
+		"handle with spaces": {
+			attributes: `name = "test"
+	handle = "on call"`,
+			wantErr: `Attribute handle must only contain lowercase letters`,
+		},
+		"malformed channel": {
+			attributes: `name = "test"
+	channels = ["C1", "general"]`,
+			wantErr: `Attribute channels\[Value\("general"\)\] must be a channel id`,
+		},
+		"malformed user": {
+			attributes: `name = "test"
+	users = ["S1"]`,
+			wantErr: `Attribute users\[Value\("S1"\)\] must be a user id`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories(client),
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig + fmt.Sprintf(`
+resource "slack_usergroup" "test" {
+	%s
+}`, tt.attributes),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(tt.wantErr),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestUserGroupResourceUpgradeStateV0(t *testing.T) {
+	t.Parallel()
+
+	attributes, ok := upgradeResourceState(t, "slack_usergroup", 0, `{
+	"id": "S1",
+	"name": "test",
+	"channels": ["C2", "C1"],
+	"users": [],
+	"description": "test",
+	"handle": "test",
+	"team_id": "T1",
+	"enabled": true
+}`)
+	if !ok {
+		return
+	}
+
+	channels := stringSetValue(t, attributes["channels"])
+	slices.Sort(channels)
+	if !slices.Equal(channels, []string{"C1", "C2"}) {
+		t.Errorf("got channels %v, want C1 and C2", channels)
+	}
+	if users := stringSetValue(t, attributes["users"]); len(users) != 0 {
+		t.Errorf("got users %v, want none", users)
+	}
+	var enabled bool
+	if err := attributes["enabled"].As(&enabled); err != nil || !enabled {
+		t.Errorf("got enabled %t, want true", enabled)
+	}
+}
+
+func TestUserGroupResourceUpgradeStateV0WithoutEnabled(t *testing.T) {
+	t.Parallel()
+
+	// A state written before enabled was added upgrades without changes.
+	attributes, ok := upgradeResourceState(t, "slack_usergroup", 0, `{
+	"id": "S1",
+	"name": "test",
+	"channels": null,
+	"users": null,
+	"team_id": "T1"
+}`)
+	if !ok {
+		return
+	}
+
+	expectEmptyPlan(t, "slack_usergroup", attributes, `{"name": "test"}`)
+}
@@ -0,0 +1,286 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/slack-go/slack"
+)
+
+const (
+	// defaultMaxRetries is the number of times a failed call is retried.
+	defaultMaxRetries = 5
+	// defaultRetryMaxWait is the longest backoff between two retries of a transient error.
+	defaultRetryMaxWait = 30 * time.Second
+	// retryBaseWait is the backoff before the first retry of a transient error.
+	retryBaseWait = time.Second
+)
+
+var _ APIClient = &retryingClient{}
+
+// retryingClient retries the calls of the APIClient it wraps when Slack rate limits them,
+// answers with a 5xx status code or can't be reached.
+// Every attempt waits for the limiter first, if there is one.
+type retryingClient struct {
+	next       APIClient
+	limiter    *methodLimiter
+	maxRetries int
+	maxWait    time.Duration
+	baseWait   time.Duration
+}
+
+func newRetryingClient(next APIClient, limiter *methodLimiter, maxRetries int, maxWait time.Duration) *retryingClient {
+	return &retryingClient{
+		next:       next,
+		limiter:    limiter,
+		maxRetries: maxRetries,
+		maxWait:    maxWait,
+		baseWait:   retryBaseWait,
+	}
+}
+
+// do calls f until it succeeds, fails with an error that isn't transient, runs out of retries
+// or the context is done. The error it gives up with is wrapped in a methodError.
+func (c *retryingClient) do(ctx context.Context, method string, f func() error) error {
+	if err := c.retry(ctx, method, f); err != nil {
+		return &methodError{method: method, err: err}
+	}
+	return nil
+}
+
+func (c *retryingClient) retry(ctx context.Context, method string, f func() error) error {
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.wait(ctx, method); err != nil {
+			return err
+		}
+		err := f()
+		if err == nil || attempt >= c.maxRetries {
+			return err
+		}
+		wait, ok := c.retryAfter(ctx, err, attempt)
+		if !ok {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return err
+		}
+		tflog.Debug(ctx, "retrying slack api call", map[string]any{
+			"method":  method,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+			"error":   err.Error(),
+		})
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// retryAfter reports whether err is transient and how long to wait before the next attempt.
+func (c *retryingClient) retryAfter(ctx context.Context, err error, attempt int) (time.Duration, bool) {
+	if ctx.Err() != nil {
+		return 0, false
+	}
+	var rateLimitedErr *slack.RateLimitedError
+	if errors.As(err, &rateLimitedErr) {
+		return rateLimitedErr.RetryAfter, true
+	}
+	if !isTransientError(err) {
+		return 0, false
+	}
+	wait := c.maxWait
+	if attempt < 32 {
+		wait = min(c.baseWait<<attempt, c.maxWait)
+	}
+	// Jitter keeps parallel resources from retrying in lockstep.
+	return wait/2 + rand.N(wait/2+1), true
+}
+
+// isTransientError reports whether err is a 5xx response or a network error.
+func isTransientError(err error) bool {
+	var statusCodeErr slack.StatusCodeError
+	if errors.As(err, &statusCodeErr) {
+		return statusCodeErr.Code >= 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func retryValue[T any](ctx context.Context, c *retryingClient, method string, f func() (T, error)) (T, error) {
+	var value T
+	err := c.do(ctx, method, func() error {
+		var err error
+		value, err = f()
+		return err
+	})
+	return value, err
+}
+
+func (c *retryingClient) GetUserByEmailContext(ctx context.Context, email string) (*slack.User, error) {
+	return retryValue(ctx, c, "users.lookupByEmail", func() (*slack.User, error) {
+		return c.next.GetUserByEmailContext(ctx, email)
+	})
+}
+
+func (c *retryingClient) CreateUserGroupContext(ctx context.Context, userGroup slack.UserGroup) (slack.UserGroup, error) {
+	return retryValue(ctx, c, "usergroups.create", func() (slack.UserGroup, error) {
+		return c.next.CreateUserGroupContext(ctx, userGroup)
+	})
+}
+
+func (c *retryingClient) GetUserGroupsContext(ctx context.Context, opts ...slack.GetUserGroupsOption) ([]slack.UserGroup, error) {
+	return retryValue(ctx, c, "usergroups.list", func() ([]slack.UserGroup, error) {
+		return c.next.GetUserGroupsContext(ctx, opts...)
+	})
+}
+
+func (c *retryingClient) UpdateUserGroupContext(ctx context.Context, userGroupID string, opts ...slack.UpdateUserGroupsOption) (slack.UserGroup, error) {
+	return retryValue(ctx, c, "usergroups.update", func() (slack.UserGroup, error) {
+		return c.next.UpdateUserGroupContext(ctx, userGroupID, opts...)
+	})
+}
+
+func (c *retryingClient) GetUserGroupMembersContext(ctx context.Context, userGroup string) ([]string, error) {
+	return retryValue(ctx, c, "usergroups.users.list", func() ([]string, error) {
+		return c.next.GetUserGroupMembersContext(ctx, userGroup)
+	})
+}
+
+func (c *retryingClient) UpdateUserGroupMembersContext(ctx context.Context, userGroup string, members string) (slack.UserGroup, error) {
+	return retryValue(ctx, c, "usergroups.users.update", func() (slack.UserGroup, error) {
+		return c.next.UpdateUserGroupMembersContext(ctx, userGroup, members)
+	})
+}
+
+func (c *retryingClient) EnableUserGroupContext(ctx context.Context, userGroup string) (slack.UserGroup, error) {
+	return retryValue(ctx, c, "usergroups.enable", func() (slack.UserGroup, error) {
+		return c.next.EnableUserGroupContext(ctx, userGroup)
+	})
+}
+
+func (c *retryingClient) DisableUserGroupContext(ctx context.Context, userGroup string) (slack.UserGroup, error) {
+	return retryValue(ctx, c, "usergroups.disable", func() (slack.UserGroup, error) {
+		return c.next.DisableUserGroupContext(ctx, userGroup)
+	})
+}
+
+func (c *retryingClient) GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	return retryValue(ctx, c, "conversations.info", func() (*slack.Channel, error) {
+		return c.next.GetConversationInfoContext(ctx, input)
+	})
+}
+
+func (c *retryingClient) GetUsersInConversationContext(ctx context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+	var cursor string
+	users, err := retryValue(ctx, c, "conversations.members", func() ([]string, error) {
+		var (
+			users []string
+			err   error
+		)
+		users, cursor, err = c.next.GetUsersInConversationContext(ctx, params)
+		return users, err
+	})
+	return users, cursor, err
+}
+
+func (c *retryingClient) GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	var cursor string
+	channels, err := retryValue(ctx, c, "conversations.list", func() ([]slack.Channel, error) {
+		var (
+			channels []slack.Channel
+			err      error
+		)
+		channels, cursor, err = c.next.GetConversationsContext(ctx, params)
+		return channels, err
+	})
+	return channels, cursor, err
+}
+
+func (c *retryingClient) CreateConversationContext(ctx context.Context, params slack.CreateConversationParams) (*slack.Channel, error) {
+	return retryValue(ctx, c, "conversations.create", func() (*slack.Channel, error) {
+		return c.next.CreateConversationContext(ctx, params)
+	})
+}
+
+func (c *retryingClient) SetTopicOfConversationContext(ctx context.Context, channelID, topic string) (*slack.Channel, error) {
+	return retryValue(ctx, c, "conversations.setTopic", func() (*slack.Channel, error) {
+		return c.next.SetTopicOfConversationContext(ctx, channelID, topic)
+	})
+}
+
+func (c *retryingClient) SetPurposeOfConversationContext(ctx context.Context, channelID, purpose string) (*slack.Channel, error) {
+	return retryValue(ctx, c, "conversations.setPurpose", func() (*slack.Channel, error) {
+		return c.next.SetPurposeOfConversationContext(ctx, channelID, purpose)
+	})
+}
+
+func (c *retryingClient) RenameConversationContext(ctx context.Context, channelID, channelName string) (*slack.Channel, error) {
+	return retryValue(ctx, c, "conversations.rename", func() (*slack.Channel, error) {
+		return c.next.RenameConversationContext(ctx, channelID, channelName)
+	})
+}
+
+func (c *retryingClient) InviteUsersToConversationContext(ctx context.Context, channelID string, users ...string) (*slack.Channel, error) {
+	return retryValue(ctx, c, "conversations.invite", func() (*slack.Channel, error) {
+		return c.next.InviteUsersToConversationContext(ctx, channelID, users...)
+	})
+}
+
+func (c *retryingClient) KickUserFromConversationContext(ctx context.Context, channelID string, user string) error {
+	return c.do(ctx, "conversations.kick", func() error {
+		return c.next.KickUserFromConversationContext(ctx, channelID, user)
+	})
+}
+
+func (c *retryingClient) ArchiveConversationContext(ctx context.Context, channelID string) error {
+	return c.do(ctx, "conversations.archive", func() error {
+		return c.next.ArchiveConversationContext(ctx, channelID)
+	})
+}
+
+func (c *retryingClient) UnArchiveConversationContext(ctx context.Context, channelID string) error {
+	return c.do(ctx, "conversations.unarchive", func() error {
+		return c.next.UnArchiveConversationContext(ctx, channelID)
+	})
+}
+
+func (c *retryingClient) CloseConversationContext(ctx context.Context, channelID string) (noOp bool, alreadyClosed bool, err error) {
+	err = c.do(ctx, "conversations.close", func() error {
+		var err error
+		noOp, alreadyClosed, err = c.next.CloseConversationContext(ctx, channelID)
+		return err
+	})
+	return noOp, alreadyClosed, err
+}
+
+func (c *retryingClient) LeaveConversationContext(ctx context.Context, channelID string) (bool, error) {
+	return retryValue(ctx, c, "conversations.leave", func() (bool, error) {
+		return c.next.LeaveConversationContext(ctx, channelID)
+	})
+}
+
+func (c *retryingClient) AdminConversationsConvertToPrivateContext(ctx context.Context, channelID string) error {
+	return c.do(ctx, "admin.conversations.convertToPrivate", func() error {
+		return c.next.AdminConversationsConvertToPrivateContext(ctx, channelID)
+	})
+}
+
+func (c *retryingClient) AdminConversationsConvertToPublicContext(ctx context.Context, channelID string) error {
+	return c.do(ctx, "admin.conversations.convertToPublic", func() error {
+		return c.next.AdminConversationsConvertToPublicContext(ctx, channelID)
+	})
+}
+
+func (c *retryingClient) AdminConversationsDeleteContext(ctx context.Context, channelID string) error {
+	return c.do(ctx, "admin.conversations.delete", func() error {
+		return c.next.AdminConversationsDeleteContext(ctx, channelID)
+	})
+}
@@ -0,0 +1,138 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sivchari/terraform-provider-slack/internal/mock"
+)
+
+func TestRetryingClient(t *testing.T) {
+	t.Parallel()
+
+	channel := &slack.Channel{GroupConversation: slack.GroupConversation{Conversation: slack.Conversation{ID: "C123"}}}
+
+	tests := map[string]struct {
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		"succeeds without retrying": {
+			wantCalls: 1,
+		},
+		"retries rate limited calls": {
+			errs:      []error{&slack.RateLimitedError{RetryAfter: time.Millisecond}, &slack.RateLimitedError{RetryAfter: time.Millisecond}},
+			wantCalls: 3,
+		},
+		"retries 5xx responses": {
+			errs:      []error{slack.StatusCodeError{Code: 503, Status: "503 Service Unavailable"}},
+			wantCalls: 2,
+		},
+		"retries network errors": {
+			errs:      []error{&net.OpError{Op: "dial", Err: errors.New("connection refused")}},
+			wantCalls: 2,
+		},
+		"doesn't retry 4xx responses": {
+			errs:      []error{slack.StatusCodeError{Code: 404, Status: "404 Not Found"}},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		"doesn't retry slack errors": {
+			errs:      []error{slack.SlackErrorResponse{Err: "channel_not_found"}},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		"gives up after max retries": {
+			errs: []error{
+				slack.StatusCodeError{Code: 500},
+				slack.StatusCodeError{Code: 500},
+				slack.StatusCodeError{Code: 500},
+				slack.StatusCodeError{Code: 500},
+			},
+			wantCalls: 3,
+			wantErr:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			next := mock.NewMockAPIClient(ctrl)
+			calls := 0
+			next.EXPECT().GetConversationInfoContext(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ *slack.GetConversationInfoInput) (*slack.Channel, error) {
+					calls++
+					if calls <= len(tt.errs) {
+						return nil, tt.errs[calls-1]
+					}
+					return channel, nil
+				},
+			).AnyTimes()
+
+			client := newRetryingClient(next, nil, 2, 10*time.Millisecond)
+			client.baseWait = time.Millisecond
+			got, err := client.GetConversationInfoContext(context.Background(), &slack.GetConversationInfoInput{ChannelID: "C123"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != channel {
+				t.Errorf("got channel %v, want %v", got, channel)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryingClientHonorsDeadline(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	next := mock.NewMockAPIClient(ctrl)
+	next.EXPECT().ArchiveConversationContext(gomock.Any(), "C123").Return(&slack.RateLimitedError{RetryAfter: time.Minute}).Times(1)
+
+	client := newRetryingClient(next, nil, 5, time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := client.ArchiveConversationContext(ctx, "C123")
+	var rateLimitedErr *slack.RateLimitedError
+	if !errors.As(err, &rateLimitedErr) {
+		t.Errorf("got error %v, want the rate limited error", err)
+		return
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("gave up after %s, want right away", elapsed)
+	}
+}
+
+func TestRetryingClientMultipleReturnValues(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	next := mock.NewMockAPIClient(ctrl)
+	gomock.InOrder(
+		next.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).Return(nil, "", &slack.RateLimitedError{RetryAfter: time.Millisecond}),
+		next.EXPECT().GetUsersInConversationContext(gomock.Any(), gomock.Any()).Return([]string{"U123"}, "next", nil),
+	)
+
+	client := newRetryingClient(next, nil, 1, time.Second)
+	users, cursor, err := client.GetUsersInConversationContext(context.Background(), &slack.GetUsersInConversationParameters{ChannelID: "C123"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(users) != 1 || users[0] != "U123" || cursor != "next" {
+		t.Errorf("got users %v and cursor %q, want [U123] and next", users, cursor)
+	}
+}
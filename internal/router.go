@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/slack-go/slack"
+)
+
+var _ APIClient = &tokenRouter{}
+
+// tokenRouter sends every call to the client holding the kind of token its method needs.
+// Methods whose kind of token isn't configured go to any other client, and Slack rejects them,
+// but resources report the missing token at plan time before it gets that far.
+type tokenRouter struct {
+	clients map[tokenKind]APIClient
+}
+
+func (c *tokenRouter) client(method string) APIClient {
+	if client, ok := c.clients[methodTokenKind(method)]; ok {
+		return client
+	}
+	for _, kind := range []tokenKind{tokenKindBot, tokenKindUser, tokenKindAdmin} {
+		if client, ok := c.clients[kind]; ok {
+			return client
+		}
+	}
+	return nil
+}
+
+func (c *tokenRouter) GetUserByEmailContext(ctx context.Context, email string) (*slack.User, error) {
+	return c.client("users.lookupByEmail").GetUserByEmailContext(ctx, email)
+}
+
+func (c *tokenRouter) CreateUserGroupContext(ctx context.Context, userGroup slack.UserGroup) (slack.UserGroup, error) {
+	return c.client("usergroups.create").CreateUserGroupContext(ctx, userGroup)
+}
+
+func (c *tokenRouter) GetUserGroupsContext(ctx context.Context, opts ...slack.GetUserGroupsOption) ([]slack.UserGroup, error) {
+	return c.client("usergroups.list").GetUserGroupsContext(ctx, opts...)
+}
+
+func (c *tokenRouter) UpdateUserGroupContext(ctx context.Context, userGroupID string, opts ...slack.UpdateUserGroupsOption) (slack.UserGroup, error) {
+	return c.client("usergroups.update").UpdateUserGroupContext(ctx, userGroupID, opts...)
+}
+
+func (c *tokenRouter) GetUserGroupMembersContext(ctx context.Context, userGroup string) ([]string, error) {
+	return c.client("usergroups.users.list").GetUserGroupMembersContext(ctx, userGroup)
+}
+
+func (c *tokenRouter) UpdateUserGroupMembersContext(ctx context.Context, userGroup string, members string) (slack.UserGroup, error) {
+	return c.client("usergroups.users.update").UpdateUserGroupMembersContext(ctx, userGroup, members)
+}
+
+func (c *tokenRouter) EnableUserGroupContext(ctx context.Context, userGroup string) (slack.UserGroup, error) {
+	return c.client("usergroups.enable").EnableUserGroupContext(ctx, userGroup)
+}
+
+func (c *tokenRouter) DisableUserGroupContext(ctx context.Context, userGroup string) (slack.UserGroup, error) {
+	return c.client("usergroups.disable").DisableUserGroupContext(ctx, userGroup)
+}
+
+func (c *tokenRouter) GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	return c.client("conversations.info").GetConversationInfoContext(ctx, input)
+}
+
+func (c *tokenRouter) GetUsersInConversationContext(ctx context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+	return c.client("conversations.members").GetUsersInConversationContext(ctx, params)
+}
+
+func (c *tokenRouter) GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	return c.client("conversations.list").GetConversationsContext(ctx, params)
+}
+
+func (c *tokenRouter) CreateConversationContext(ctx context.Context, params slack.CreateConversationParams) (*slack.Channel, error) {
+	return c.client("conversations.create").CreateConversationContext(ctx, params)
+}
+
+func (c *tokenRouter) SetTopicOfConversationContext(ctx context.Context, channelID, topic string) (*slack.Channel, error) {
+	return c.client("conversations.setTopic").SetTopicOfConversationContext(ctx, channelID, topic)
+}
+
+func (c *tokenRouter) SetPurposeOfConversationContext(ctx context.Context, channelID, purpose string) (*slack.Channel, error) {
+	return c.client("conversations.setPurpose").SetPurposeOfConversationContext(ctx, channelID, purpose)
+}
+
+func (c *tokenRouter) RenameConversationContext(ctx context.Context, channelID, channelName string) (*slack.Channel, error) {
+	return c.client("conversations.rename").RenameConversationContext(ctx, channelID, channelName)
+}
+
+func (c *tokenRouter) InviteUsersToConversationContext(ctx context.Context, channelID string, users ...string) (*slack.Channel, error) {
+	return c.client("conversations.invite").InviteUsersToConversationContext(ctx, channelID, users...)
+}
+
+func (c *tokenRouter) KickUserFromConversationContext(ctx context.Context, channelID string, user string) error {
+	return c.client("conversations.kick").KickUserFromConversationContext(ctx, channelID, user)
+}
+
+func (c *tokenRouter) ArchiveConversationContext(ctx context.Context, channelID string) error {
+	return c.client("conversations.archive").ArchiveConversationContext(ctx, channelID)
+}
+
+func (c *tokenRouter) UnArchiveConversationContext(ctx context.Context, channelID string) error {
+	return c.client("conversations.unarchive").UnArchiveConversationContext(ctx, channelID)
+}
+
+func (c *tokenRouter) CloseConversationContext(ctx context.Context, channelID string) (noOp bool, alreadyClosed bool, err error) {
+	return c.client("conversations.close").CloseConversationContext(ctx, channelID)
+}
+
+func (c *tokenRouter) LeaveConversationContext(ctx context.Context, channelID string) (bool, error) {
+	return c.client("conversations.leave").LeaveConversationContext(ctx, channelID)
+}
+
+func (c *tokenRouter) AdminConversationsConvertToPrivateContext(ctx context.Context, channelID string) error {
+	return c.client("admin.conversations.convertToPrivate").AdminConversationsConvertToPrivateContext(ctx, channelID)
+}
+
+func (c *tokenRouter) AdminConversationsConvertToPublicContext(ctx context.Context, channelID string) error {
+	return c.client("admin.conversations.convertToPublic").AdminConversationsConvertToPublicContext(ctx, channelID)
+}
+
+func (c *tokenRouter) AdminConversationsDeleteContext(ctx context.Context, channelID string) error {
+	return c.client("admin.conversations.delete").AdminConversationsDeleteContext(ctx, channelID)
+}
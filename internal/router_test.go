@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sivchari/terraform-provider-slack/internal/mock"
+)
+
+func TestTokenRouter(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	bot := mock.NewMockAPIClient(ctrl)
+	user := mock.NewMockAPIClient(ctrl)
+
+	bot.EXPECT().ArchiveConversationContext(gomock.Any(), "C123").Return(nil).Times(1)
+	user.EXPECT().EnableUserGroupContext(gomock.Any(), "S123").Return(slack.UserGroup{}, nil).Times(1)
+	// Without an admin token, admin methods go to the bot token and Slack rejects them.
+	bot.EXPECT().AdminConversationsDeleteContext(gomock.Any(), "C123").Return(slack.SlackErrorResponse{Err: "not_allowed_token_type"}).Times(1)
+
+	router := &tokenRouter{clients: map[tokenKind]APIClient{
+		tokenKindBot:  bot,
+		tokenKindUser: user,
+	}}
+	ctx := context.Background()
+	if err := router.ArchiveConversationContext(ctx, "C123"); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := router.EnableUserGroupContext(ctx, "S123"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := router.AdminConversationsDeleteContext(ctx, "C123"); !isSlackError(err, "not_allowed_token_type") {
+		t.Errorf("got error %v, want not_allowed_token_type", err)
+	}
+}
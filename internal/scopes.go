@@ -0,0 +1,30 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// grantedScopes are the OAuth scopes granted to each kind of token, as reported by auth.test.
+type grantedScopes map[tokenKind][]string
+
+// require reports an error on diags when the token of kind was granted none of anyOf, which what needs.
+// Nothing is reported when the scopes are unknown, i.e. before the provider is configured
+// or when Slack doesn't list the scopes of the token.
+func (s grantedScopes) require(diags *diag.Diagnostics, kind tokenKind, what string, anyOf ...string) {
+	scopes, ok := s[kind]
+	if !ok {
+		return
+	}
+	if slices.ContainsFunc(anyOf, func(scope string) bool { return slices.Contains(scopes, scope) }) {
+		return
+	}
+	scope := strings.Join(anyOf, " or ")
+	diags.AddError(
+		fmt.Sprintf("missing %s scope", scope),
+		fmt.Sprintf("%s needs the %s scope, which the %s token wasn't granted. Add it to the Slack app and reinstall the app.", what, scope, kind),
+	)
+}
@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/slack-go/slack"
+)
+
+// refreshOptionalString returns the remote value, keeping null when the attribute is unset and Slack reports an empty value.
+func refreshOptionalString(prior types.String, remote string) types.String {
+	if prior.IsNull() && remote == "" {
+		return prior
+	}
+	return types.StringValue(remote)
+}
+
+// teamIDOrDefault returns the team id set on the resource, or else the default one of the provider.
+func teamIDOrDefault(teamID types.String, defaultTeamID string) string {
+	if teamID.ValueString() != "" {
+		return teamID.ValueString()
+	}
+	return defaultTeamID
+}
+
+// boolOrDefault returns value, or else the default of an attribute missing from a state written by an older version.
+func boolOrDefault(value types.Bool, defaultValue bool) types.Bool {
+	if value.IsNull() {
+		return types.BoolValue(defaultValue)
+	}
+	return value
+}
+
+// stringOrDefault returns value, or else the default of an attribute missing from a state written by an older version.
+func stringOrDefault(value types.String, defaultValue string) types.String {
+	if value.IsNull() {
+		return types.StringValue(defaultValue)
+	}
+	return value
+}
+
+// requiresReplaceIfTeamIDKnown replaces the resource when its team_id changes.
+// A state written before team_id was tracked has it null, which is filled in place rather than replacing the resource.
+func requiresReplaceIfTeamIDKnown() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.StringRequest, res *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			res.RequiresReplace = !req.StateValue.IsNull()
+		},
+		"Changing the workspace replaces the resource, unless the workspace wasn't recorded yet.",
+		"Changing the workspace replaces the resource, unless the workspace wasn't recorded yet.",
+	)
+}
+
+// refreshStringSet returns the remote values as a set.
+// Null is kept when the attribute is unset and Slack reports nothing.
+func refreshStringSet(ctx context.Context, prior types.Set, remote []string) (types.Set, diag.Diagnostics) {
+	if prior.IsNull() && len(remote) == 0 {
+		return prior, nil
+	}
+	return stringSet(ctx, remote)
+}
+
+// stringSet returns values as a set, dropping the duplicates.
+func stringSet(ctx context.Context, values []string) (types.Set, diag.Diagnostics) {
+	values = slices.Clone(values)
+	slices.Sort(values)
+	return types.SetValueFrom(ctx, types.StringType, slices.Compact(values))
+}
+
+// listToSet returns the values of list as a set, dropping the duplicates.
+func listToSet(ctx context.Context, list types.List) (types.Set, diag.Diagnostics) {
+	if list.IsNull() {
+		return types.SetNull(types.StringType), nil
+	}
+	var values []string
+	if diags := list.ElementsAs(ctx, &values, false); diags.HasError() {
+		return types.SetNull(types.StringType), diags
+	}
+	return stringSet(ctx, values)
+}
+
+// isSlackError reports whether err is an error response from the Slack API with one of the given codes.
+func isSlackError(err error, codes ...string) bool {
+	var slackErr slack.SlackErrorResponse
+	if !errors.As(err, &slackErr) {
+		return false
+	}
+	return slices.Contains(codes, slackErr.Err)
+}
+
+// addIncompleteWarnings reports the errors of the steps that failed after object was created as warnings.
+// Terraform taints a resource whose create fails, and replacing it would collide with the name the object still holds,
+// so the object is kept in state instead and the next apply retries the failed steps.
+func addIncompleteWarnings(diags *diag.Diagnostics, object string, incomplete diag.Diagnostics) {
+	for _, d := range incomplete {
+		if d.Severity() != diag.SeverityError {
+			diags.Append(d)
+			continue
+		}
+		diags.AddWarning(
+			fmt.Sprintf("%s was created, but %s", object, d.Summary()),
+			d.Detail()+"\n\nThe next apply retries it.",
+		)
+	}
+}
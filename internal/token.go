@@ -0,0 +1,128 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// tokenEnvVar is the environment variable the token is read from when the provider block doesn't set it.
+const tokenEnvVar = "SLACK_TOKEN"
+
+// The sources a token can be resolved from, in the order they are tried.
+const (
+	tokenSourceConfig  = "token"
+	tokenSourceEnv     = tokenEnvVar
+	tokenSourceFile    = "token_file"
+	tokenSourceCommand = "token_command"
+)
+
+var errNoToken = errors.New("set the token, the " + tokenEnvVar + " environment variable, token_file, token_command or bot_token, user_token and admin_token")
+
+// resolveToken returns the token from the first source that is set, along with the name of that source.
+func resolveToken(ctx context.Context, token, tokenFile types.String, tokenCommand types.List) (string, string, error) {
+	if token.ValueString() != "" {
+		return token.ValueString(), tokenSourceConfig, nil
+	}
+	if env := os.Getenv(tokenEnvVar); env != "" {
+		return env, tokenSourceEnv, nil
+	}
+	if tokenFile.ValueString() != "" {
+		b, err := os.ReadFile(tokenFile.ValueString())
+		if err != nil {
+			return "", tokenSourceFile, err
+		}
+		token := strings.TrimSpace(string(b))
+		if token == "" {
+			return "", tokenSourceFile, fmt.Errorf("the token file %s is empty", tokenFile.ValueString())
+		}
+		return token, tokenSourceFile, nil
+	}
+	if !tokenCommand.IsNull() && len(tokenCommand.Elements()) > 0 {
+		var args []string
+		if diags := tokenCommand.ElementsAs(ctx, &args, false); diags.HasError() {
+			return "", tokenSourceCommand, errors.New("token_command must be a list of strings")
+		}
+		token, err := runTokenCommand(ctx, args)
+		return token, tokenSourceCommand, err
+	}
+	return "", "", errNoToken
+}
+
+// runTokenCommand runs the credential helper and returns the token it prints on stdout.
+// Only stderr ends up in the error, since stdout holds the secret.
+func runTokenCommand(ctx context.Context, args []string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("the token command %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("the token command %s printed no token", args[0])
+	}
+	return token, nil
+}
+
+// tokenKind is the kind of Slack token a Web API method needs.
+type tokenKind string
+
+const (
+	tokenKindBot   tokenKind = "bot"
+	tokenKindUser  tokenKind = "user"
+	tokenKindAdmin tokenKind = "admin"
+)
+
+// tokenKinds is the set of kinds of token the provider is configured with.
+type tokenKinds map[tokenKind]bool
+
+// methodTokenKind returns the kind of token method is sent with.
+func methodTokenKind(method string) tokenKind {
+	switch {
+	case strings.HasPrefix(method, "usergroups."):
+		return tokenKindUser
+	case strings.HasPrefix(method, "admin."):
+		return tokenKindAdmin
+	default:
+		return tokenKindBot
+	}
+}
+
+// kindTokens returns the token of each kind, which is the token of the provider unless a token of that kind is set.
+func kindTokens(token string, botToken, userToken, adminToken types.String) map[tokenKind]string {
+	tokens := make(map[tokenKind]string)
+	for kind, kindToken := range map[tokenKind]types.String{
+		tokenKindBot:   botToken,
+		tokenKindUser:  userToken,
+		tokenKindAdmin: adminToken,
+	} {
+		switch {
+		case kindToken.ValueString() != "":
+			tokens[kind] = kindToken.ValueString()
+		case token != "":
+			tokens[kind] = token
+		}
+	}
+	return tokens
+}
+
+// require reports an error on diags when the provider lacks the kind of token needed by what.
+// Nothing is reported before the provider is configured.
+func (k tokenKinds) require(diags *diag.Diagnostics, kind tokenKind, what string) {
+	if k == nil || k[kind] {
+		return
+	}
+	diags.AddError(
+		fmt.Sprintf("missing %s token", kind),
+		fmt.Sprintf("%s needs the %s token. Set %s_token, or token, in the provider block.", what, kind, kind),
+	)
+}
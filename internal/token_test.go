@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResolveToken(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("xoxb-file\n"), 0o600); err != nil {
+		t.Error(err)
+		return
+	}
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, nil, 0o600); err != nil {
+		t.Error(err)
+		return
+	}
+
+	command := func(args ...string) types.List {
+		values := make([]attr.Value, 0, len(args))
+		for _, arg := range args {
+			values = append(values, types.StringValue(arg))
+		}
+		return types.ListValueMust(types.StringType, values)
+	}
+
+	tests := map[string]struct {
+		env          string
+		token        types.String
+		tokenFile    types.String
+		tokenCommand types.List
+		wantToken    string
+		wantSource   string
+		wantErr      bool
+	}{
+		"token": {
+			env:        "xoxb-env",
+			token:      types.StringValue("xoxb-config"),
+			tokenFile:  types.StringValue(tokenFile),
+			wantToken:  "xoxb-config",
+			wantSource: "token",
+		},
+		"environment variable": {
+			env:        "xoxb-env",
+			tokenFile:  types.StringValue(tokenFile),
+			wantToken:  "xoxb-env",
+			wantSource: "SLACK_TOKEN",
+		},
+		"token file": {
+			tokenFile:    types.StringValue(tokenFile),
+			tokenCommand: command("echo", "xoxb-command"),
+			wantToken:    "xoxb-file",
+			wantSource:   "token_file",
+		},
+		"empty token file": {
+			tokenFile:  types.StringValue(emptyFile),
+			wantSource: "token_file",
+			wantErr:    true,
+		},
+		"missing token file": {
+			tokenFile:  types.StringValue(filepath.Join(dir, "missing")),
+			wantSource: "token_file",
+			wantErr:    true,
+		},
+		"token command": {
+			tokenCommand: command("echo", "xoxb-command"),
+			wantToken:    "xoxb-command",
+			wantSource:   "token_command",
+		},
+		"failing token command": {
+			tokenCommand: command("false"),
+			wantSource:   "token_command",
+			wantErr:      true,
+		},
+		"no token": {
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(tokenEnvVar, tt.env)
+			tokenCommand := tt.tokenCommand
+			if tokenCommand.IsNull() {
+				tokenCommand = types.ListNull(types.StringType)
+			}
+
+			token, source, err := resolveToken(context.Background(), tt.token, tt.tokenFile, tokenCommand)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+				return
+			}
+			if token != tt.wantToken {
+				t.Errorf("got token %q, want %q", token, tt.wantToken)
+			}
+			if source != tt.wantSource {
+				t.Errorf("got source %q, want %q", source, tt.wantSource)
+			}
+		})
+	}
+}
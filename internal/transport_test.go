@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNormalizeAPIURL(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		apiURL  string
+		want    string
+		wantErr bool
+	}{
+		"trailing slash":    {apiURL: "https://slack-gov.com/api/", want: "https://slack-gov.com/api/"},
+		"no trailing slash": {apiURL: "http://localhost:8080/api", want: "http://localhost:8080/api/"},
+		"relative":          {apiURL: "slack.com/api", wantErr: true},
+		"unknown scheme":    {apiURL: "ftp://slack.com/api", wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := normalizeAPIURL(tt.apiURL)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClientCACertFile(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caCertFile, caCert, 0o600); err != nil {
+		t.Error(err)
+		return
+	}
+
+	untrusting, err := newHTTPClient("", "", time.Second)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if resp, err := untrusting.Get(server.URL); err == nil {
+		resp.Body.Close()
+		t.Error("got no error calling a server with an untrusted certificate")
+		return
+	}
+
+	trusting, err := newHTTPClient("", caCertFile, time.Second)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	resp, err := trusting.Get(server.URL)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	resp.Body.Close()
+
+	if _, err := newHTTPClient("", filepath.Join(t.TempDir(), "missing.pem"), time.Second); err == nil {
+		t.Error("got no error for a missing ca cert file")
+	}
+}
+
+func TestNewHTTPClientProxy(t *testing.T) {
+	t.Parallel()
+
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	client, err := newHTTPClient(proxy.URL, "", time.Second)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	resp, err := client.Get("http://slack.invalid/api/auth.test")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	resp.Body.Close()
+	if proxied != "http://slack.invalid/api/auth.test" {
+		t.Errorf("got proxied request %q, want http://slack.invalid/api/auth.test", proxied)
+	}
+}
@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// conversationNameChars are the characters Slack allows in a conversation name: lowercase letters,
+// letters of scripts without case such as Japanese, numbers, hyphens and underscores.
+const conversationNameChars = `\p{Ll}\p{Lm}\p{Lo}\p{Nd}_-`
+
+// conversationNameRegexp matches a name made of the characters Slack allows in conversation names.
+var conversationNameRegexp = regexp.MustCompile(`^[` + conversationNameChars + `]+$`)
+
+// conversationNameValidators check a conversation name against the naming rules of Slack.
+func conversationNameValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.LengthBetween(1, maxConversationNameLength),
+		stringvalidator.RegexMatches(
+			conversationNameRegexp,
+			"must only contain lowercase letters, letters without case, numbers, hyphens and underscores",
+		),
+	}
+}
+
+// userGroupHandleValidator checks a usergroup handle, which is mentioned as @handle.
+func userGroupHandleValidator() validator.String {
+	return stringvalidator.RegexMatches(
+		regexp.MustCompile(`^[a-z0-9._-]+$`),
+		"must only contain lowercase letters, numbers, periods, hyphens and underscores",
+	)
+}
+
+// userIDValidator checks that a value is a user id, which starts with U, or W in Enterprise Grid.
+func userIDValidator() validator.String {
+	return stringvalidator.RegexMatches(regexp.MustCompile(`^[UW][A-Z0-9]+$`), "must be a user id, which starts with U or W")
+}
+
+// conversationIDValidator checks that a value is the id of a channel, which starts with C, or G for older private channels.
+func conversationIDValidator() validator.String {
+	return stringvalidator.RegexMatches(regexp.MustCompile(`^[CG][A-Z0-9]+$`), "must be a channel id, which starts with C or G")
+}
+
+// userGroupIDValidator checks that a value is a usergroup id, which starts with S.
+func userGroupIDValidator() validator.String {
+	return stringvalidator.RegexMatches(regexp.MustCompile(`^S[A-Z0-9]+$`), "must be a usergroup id, which starts with S")
+}